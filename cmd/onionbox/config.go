@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// configCmd runs the config subcommand. It returns the process exit code.
+func configCmd(args []string) int {
+	if len(args) == 0 || args[0] != "check" {
+		fmt.Fprintln(os.Stderr, "Usage: onionbox config check [-config <file>] [flags]")
+		if len(args) > 0 && (args[0] == "-h" || args[0] == "-help") {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	fs := newFlagSet("config check", "[-config <file>] [flags]", "Load the configuration from a file, the environment and flags, and report\nany invalid settings.")
+	cfg, code := loadConfig(fs, args[1:])
+	if cfg == nil {
+		return code
+	}
+	fmt.Println("Configuration OK")
+	return exitOK
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+)
+
+// decrypt decrypts a file encrypted with a share password, such as a
+// password protected share saved before it was decrypted.
+func decrypt(args []string) int {
+	fs := newFlagSet("decrypt", "[flags] <file>", "Decrypt a file encrypted with a share password. Reads standard input if\nthe file is -.")
+	password := passwordFlag(fs, "password the file was encrypted with")
+	out := fs.String("o", "-", "file to write the decrypted contents to, or - for standard output")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 || *password == "" {
+		fs.Usage()
+		return exitUsage
+	}
+
+	var data []byte
+	var err error
+	if fs.Arg(0) == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(fs.Arg(0))
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+		return exitError
+	}
+	plain, err := onionbuffer.Decrypt(data, *password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error decrypting file: %v\n", err)
+		return exitError
+	}
+
+	w, path, err := createOutput(*out, "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating output file: %v\n", err)
+		return exitError
+	}
+	_, err = w.Write(plain)
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing file: %v\n", err)
+		return exitError
+	}
+	if path != "" {
+		fmt.Fprintf(os.Stderr, "Decrypted file written to %s\n", path)
+	}
+	return exitOK
+}
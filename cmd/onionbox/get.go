@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionbox"
+	"github.com/ciehanski/onionbox/onionbuffer"
+)
+
+// torClientFlags defines the flags of the subcommands acting as Tor clients
+// and returns the onionbox instance they configure.
+func torClientFlags(fs *flag.FlagSet) *onionbox.Onionbox {
+	ob := &onionbox.Onionbox{}
+	fs.StringVar(&ob.TorrcFile, "torrc", "", "location of a custom torrc file")
+	fs.BoolVar(&ob.Debug, "debug", false, "run in debug mode")
+	fs.Func("tor-control", "use a running Tor through its control port (host:port or unix:path) instead of the embedded one, authenticating with $ONIONBOX_TOR_CONTROL_PASSWORD if set", func(addr string) error {
+		ob.TorBackend = &onionbox.ExternalTor{Addr: addr, Password: os.Getenv("ONIONBOX_TOR_CONTROL_PASSWORD")}
+		return nil
+	})
+	return ob
+}
+
+// torClient bootstraps Tor, waiting at most timeout, and returns an HTTP
+// client using it. The returned function stops Tor.
+func torClient(ob *onionbox.Onionbox, timeout time.Duration) (*http.Client, func() error, error) {
+	if ob.Debug {
+		ob.Logger = onionbox.NewLogger(os.Stderr, slog.LevelDebug, false)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	fmt.Fprintln(os.Stderr, "Connecting to Tor...")
+	client, stop, err := ob.TorClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Keep the CSRF cookie between the form and its submission
+	client.Jar, _ = cookiejar.New(nil)
+	return client, stop, nil
+}
+
+// get downloads a share over Tor.
+func get(args []string) int {
+	fs := newFlagSet("get", "[flags] <share url>", "Download a share over Tor and check it against its published checksum.")
+	ob := torClientFlags(fs)
+	password := passwordFlag(fs, "password of a protected share")
+	out := fs.String("o", "", "file to write the share to, or - for standard output (default: the share's file name)")
+	timeout := fs.Duration("timeout", 3*time.Minute, "time allowed to connect to Tor")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	shareURL := fs.Arg(0)
+	if !strings.Contains(shareURL, "://") {
+		shareURL = "http://" + shareURL
+	}
+	if _, err := url.Parse(shareURL); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid share URL: %v\n", err)
+		return exitUsage
+	}
+
+	client, stop, err := torClient(ob, *timeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error connecting to Tor: %v\n", err)
+		return exitError
+	}
+	defer stop()
+
+	res, err := fetchShare(client, shareURL, *password)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error downloading share: %v\n", err)
+		return exitError
+	}
+	defer res.Body.Close()
+
+	w, path, err := createOutput(*out, res.Header.Get("Content-Disposition"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error creating output file: %v\n", err)
+		return exitError
+	}
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(w, hash), res.Body)
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing share: %v\n", err)
+		return exitError
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	if digest := res.Header.Get("Repr-Digest"); digest != "" {
+		if want, _, err := onionbuffer.DigestHeaders(sum); err != nil || want != digest {
+			fmt.Fprintf(os.Stderr, "Share checksum MISMATCH: got %s, server sent %s\n", sum, digest)
+			return exitError
+		}
+	}
+	if path != "" {
+		fmt.Fprintf(os.Stderr, "Saved share to %s\n", path)
+	}
+	fmt.Fprintf(os.Stderr, "SHA-256: %s\n", sum)
+	return exitOK
+}
+
+// fetchShare requests the share at shareURL, submitting the password form
+// first when a password is given.
+func fetchShare(client *http.Client, shareURL, password string) (*http.Response, error) {
+	var res *http.Response
+	var err error
+	if password == "" {
+		res, err = client.Get(shareURL)
+	} else {
+		// The form page sets the CSRF cookie the submission must echo
+		if res, err = client.Get(shareURL); err != nil {
+			return nil, err
+		}
+		res.Body.Close()
+		u, _ := url.Parse(shareURL)
+		var token string
+		for _, c := range client.Jar.Cookies(u) {
+			if c.Name == "X-CSRF-Token" {
+				token = c.Value
+			}
+		}
+		if token == "" {
+			return nil, fmt.Errorf("share is not password protected")
+		}
+		res, err = client.PostForm(shareURL, url.Values{"token": {token}, "password": {password}})
+	}
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		res.Body.Close()
+		return nil, fmt.Errorf("%s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+	return res, nil
+}
+
+// createOutput opens where the share is written: out if set, otherwise the
+// file name the server suggested. It never overwrites an existing file and
+// returns the path written to, empty for standard output.
+func createOutput(out, disposition string) (io.WriteCloser, string, error) {
+	if out == "-" {
+		return nopWriteCloser{os.Stdout}, "", nil
+	}
+	if out == "" {
+		// Only keep the base name so a server cannot choose the directory
+		if _, params, err := mime.ParseMediaType(disposition); err == nil {
+			out = filepath.Base(filepath.Clean("/" + params["filename"]))
+		}
+		if out == "" || out == "/" || out == "." {
+			out = "share"
+		}
+	}
+	f, err := os.OpenFile(out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, "", err
+	}
+	return f, out, nil
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
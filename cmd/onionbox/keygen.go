@@ -0,0 +1,107 @@
+package main
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"os"
+
+	"github.com/cretz/bine/torutil"
+	toreddsa "github.com/cretz/bine/torutil/ed25519"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+)
+
+// keygen generates an ed25519 key for signing share manifests, which can
+// also serve as the onion service's identity key.
+func keygen(args []string) int {
+	fs := newFlagSet("keygen", "[flags]", "Generate an ed25519 manifest signing key. Use it with -signkey, adding\n-signkey-onion to also make it the persistent onion address, or as a\nservice's key. With -client-auth, generate an x25519 client authorization\nkey pair instead.")
+	out := fs.String("o", "", "file to write the private key to (default signing.pem, or client_auth.key with -client-auth)")
+	force := fs.Bool("force", false, "overwrite an existing key file")
+	clientAuth := fs.Bool("client-auth", false, "generate a client authorization key pair for a service's client_auth")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *clientAuth {
+		if *out == "" {
+			*out = "client_auth.key"
+		}
+		return keygenClientAuth(*out, *force)
+	}
+	if *out == "" {
+		*out = "signing.pem"
+	}
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating key: %v\n", err)
+		return exitError
+	}
+	privPEM, err := onionbuffer.MarshalSigningKey(priv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding private key: %v\n", err)
+		return exitError
+	}
+	pubPEM, err := onionbuffer.MarshalVerifyKey(pub)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding public key: %v\n", err)
+		return exitError
+	}
+
+	if err := writeKeyFile(*out, privPEM, *force); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing key file: %v\n", err)
+		return exitError
+	}
+
+	fmt.Fprintf(os.Stderr, "Private key written to %s\n", *out)
+	fmt.Print(string(pubPEM))
+	onion := torutil.OnionServiceIDFromV3PublicKey(toreddsa.PublicKey(pub))
+	fmt.Fprintf(os.Stderr, "Onion address with -signkey-onion: http://%s.onion\n", onion)
+	return exitOK
+}
+
+// keygenClientAuth generates an x25519 key pair authorizing a client to
+// reach an onion service. The public key goes in the service's
+// client_auth, the private key to the client, such as Tor Browser.
+func keygenClientAuth(out string, force bool) int {
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating key: %v\n", err)
+		return exitError
+	}
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	privKey := encoding.EncodeToString(priv.Bytes())
+	if err := writeKeyFile(out, []byte(privKey+"\n"), force); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing key file: %v\n", err)
+		return exitError
+	}
+
+	fmt.Fprintf(os.Stderr, "Private key for the client written to %s\n", out)
+	fmt.Fprintln(os.Stderr, "Public key for the service's client_auth:")
+	fmt.Printf("descriptor:x25519:%s\n", encoding.EncodeToString(priv.PublicKey().Bytes()))
+	return exitOK
+}
+
+// writeKeyFile writes a private key readable only by its owner, refusing to
+// overwrite an existing file unless force is set.
+func writeKeyFile(path string, key []byte, force bool) error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, err := os.OpenFile(path, flags, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(key)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
@@ -1,75 +1,78 @@
 package main
 
 import (
-	"context"
-	"flag"
 	"fmt"
-	"log"
 	"os"
-	"time"
+	"strings"
+)
+
+// version is set at build time with -ldflags "-X main.version=<version>".
+var version = "dev"
 
-	"github.com/ciehanski/onionbox/onionbox"
-	"github.com/ciehanski/onionbox/onionstore"
+// Exit codes shared by every subcommand.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
 )
 
-func main() {
-	// Create onionbox instance that stores config
-	ob := onionbox.Onionbox{
-		Logger: log.New(os.Stdout, "[onionbox] ", log.LstdFlags|log.Lshortfile),
-		Store:  onionstore.NewStore(),
-	}
+// command is an onionbox subcommand. run returns the process exit code.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
 
-	// Init flags
-	flag.BoolVar(&ob.Debug, "debug", false, "run in debug mode")
-	flag.BoolVar(&ob.TorVersion3, "torv3", true, "use version 3 of the Tor circuit (recommended)")
-	flag.IntVar(&ob.RemotePort, "rport", 80, "remote port used to host the onion service")
-	flag.IntVar(&ob.LocalPort, "lport", 0, "local port used to host the onion service")
-	flag.StringVar(&ob.TorrcFile, "torrc", "", "provide a custom torrc file for the onion service")
-	flag.Parse()
+// commands lists every subcommand in the order they are shown in usage.
+func commands() []command {
+	return []command{
+		{"serve", "run the onionbox server (default)", serve},
+		{"share", "serve local files as a share", share},
+		{"receive", "run the server for someone to upload files to you", receive},
+		{"get", "download a share over Tor", get},
+		{"put", "upload files to an onionbox server over Tor", put},
+		{"keygen", "generate an ed25519 manifest signing key", keygen},
+		{"decrypt", "decrypt a file encrypted with a share password", decrypt},
+		{"verify", "verify a signed share manifest", verify},
+		{"config", "check a configuration file", configCmd},
+		{"version", "print version information", versionCmd},
+	}
+}
 
-	// Wait at most 3 minutes to publish the service
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
-	defer cancel()
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
 
-	// Init Tor connection
-	t, onionSvc, err := ob.Init(ctx)
-	if err != nil {
-		ob.Logf("Error starting Tor & initializing onion service: %v", err)
-		ob.Quit()
+// run dispatches args to their subcommand and returns the process exit code.
+func run(args []string) int {
+	// serve stays the default so flags alone keep starting the server
+	name := "serve"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
 	}
-	defer func() {
-		if err = onionSvc.Close(); err != nil {
-			ob.Logf("Error closing connection to onion service: %v", err)
-			ob.Quit()
-		}
-		if err = t.Close(); err != nil {
-			ob.Logf("Error closing connection to Tor: %v", err)
-			ob.Quit()
+	if name == "help" {
+		if len(args) == 0 {
+			usage()
+			return exitOK
 		}
-	}()
-
-	//Create a separate go routine which infinitely loops through the store to check for
-	//expired buffer entries, and delete them.
-	go func() {
-		if err = ob.Store.DestroyExpiredBuffers(); err != nil {
-			ob.Logf("Error destroying expired buffers: %v", err)
+		name, args = args[0], []string{"-h"}
+	}
+	for _, cmd := range commands() {
+		if cmd.name == name {
+			return cmd.run(args)
 		}
-	}()
-
-	// Display the onion service URL
-	ob.OnionURL = onionSvc.ID
-	fmt.Printf("Please open a Tor capable browser and navigate to http://%v.onion\n", ob.OnionURL)
+	}
+	fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", name)
+	usage()
+	return exitUsage
+}
 
-	srvErrCh := make(chan error, 1)
-	go func() { srvErrCh <- ob.Server.Serve(onionSvc) }() // Begin serving
-	if err = <-srvErrCh; err != nil {
-		ob.Logf("Error serving on onion service: %v", err)
-		ob.Quit()
+// usage prints the list of subcommands.
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: onionbox [command] [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+	for _, cmd := range commands() {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", cmd.name, cmd.summary)
 	}
-	defer func() { // Proper server shutdown when program ends
-		if err = ob.Server.Shutdown(ctx); err != nil {
-			ob.Logf("Error shutting down onionbox server: %v", err)
-			ob.Quit()
-		}
-	}()
+	fmt.Fprintln(os.Stderr, "\nRun 'onionbox help <command>' for a command's flags.")
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// capture runs f with stdout and stderr redirected, returning both.
+func capture(t *testing.T, f func()) (string, string) {
+	read := func(file **os.File) func() string {
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		saved := *file
+		*file = w
+		out := make(chan string)
+		go func() {
+			b, _ := io.ReadAll(r)
+			out <- string(b)
+		}()
+		return func() string {
+			*file = saved
+			w.Close()
+			return <-out
+		}
+	}
+	stdout, stderr := read(&os.Stdout), read(&os.Stderr)
+	f()
+	return stdout(), stderr()
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		args   []string
+		code   int
+		output string
+	}{
+		// Flags alone keep starting the server
+		{[]string{"-h"}, exitOK, "Usage: onionbox serve"},
+		{[]string{"help"}, exitOK, "Commands:"},
+		{[]string{"help", "get"}, exitOK, "Usage: onionbox get"},
+		{[]string{"keygen", "-h"}, exitOK, "Usage: onionbox keygen"},
+		{[]string{"version"}, exitOK, "onionbox dev"},
+		{[]string{"bogus"}, exitUsage, `Unknown command "bogus"`},
+		{[]string{"help", "bogus"}, exitUsage, `Unknown command "bogus"`},
+		{[]string{"version", "-bogus"}, exitUsage, "flag provided but not defined"},
+		{[]string{"config"}, exitUsage, "Usage: onionbox config check"},
+		{[]string{"decrypt"}, exitUsage, "Usage: onionbox decrypt"},
+		{[]string{"decrypt", "-password", "hunter2", "testdata/missing"}, exitError, "missing"},
+		{[]string{"config", "check", "-config", "testdata/missing.toml"}, exitError, "Error loading configuration"},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			var code int
+			stdout, stderr := capture(t, func() { code = run(tt.args) })
+			if code != tt.code {
+				t.Errorf("Expected exit code %d, got %d", tt.code, code)
+			}
+			if !strings.Contains(stdout+stderr, tt.output) {
+				t.Errorf("Expected output containing %q, got %q", tt.output, stdout+stderr)
+			}
+		})
+	}
+}
+
+func TestRunHelp(t *testing.T) {
+	// Every command has its own help
+	for _, cmd := range commands() {
+		var code int
+		_, stderr := capture(t, func() { code = run([]string{"help", cmd.name}) })
+		if code != exitOK {
+			t.Errorf("%s: expected exit code %d, got %d", cmd.name, exitOK, code)
+		}
+		if !strings.Contains(stderr, "Usage: onionbox "+cmd.name) {
+			t.Errorf("%s: expected its usage, got %q", cmd.name, stderr)
+		}
+	}
+}
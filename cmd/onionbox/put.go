@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+)
+
+// put uploads local files to an onionbox server over Tor.
+func put(args []string) int {
+	fs := newFlagSet("put", "[flags] <server url> <file>...", "Upload files to an onionbox server over Tor and print the share's address.")
+	ob := torClientFlags(fs)
+	archive := fs.String("archive", string(onionbuffer.ArchiveZip), "archive format: zip, tar.gz, tar.zst or none (single file only)")
+	password := passwordFlag(fs, "password protecting the share")
+	downloads := fs.Int64("downloads", 0, "number of downloads allowed (0 for the server's default)")
+	expire := fs.Duration("expire", 0, "destroy the share after this long, rounded up to the minute (0 for the server's default)")
+	burn := fs.Bool("burn", false, "destroy the share after its first successful download")
+	timeout := fs.Duration("timeout", 3*time.Minute, "time allowed to connect to Tor")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return exitUsage
+	}
+	serverURL := fs.Arg(0)
+	if !strings.Contains(serverURL, "://") {
+		serverURL = "http://" + serverURL
+	}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid server URL: %v\n", err)
+		return exitUsage
+	}
+	u.Path = "/"
+	var files []onionbuffer.SourceFile
+	for _, path := range fs.Args()[1:] {
+		f, err := onionbuffer.LocalFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+			return exitError
+		}
+		files = append(files, f)
+	}
+
+	fields := map[string]string{"archive": *archive}
+	if *password != "" {
+		fields["password_enabled"] = "on"
+		fields["password"] = *password
+	}
+	if *downloads > 0 {
+		fields["limit_downloads"] = "on"
+		fields["download_limit"] = strconv.FormatInt(*downloads, 10)
+	}
+	if *expire > 0 {
+		fields["expire"] = "on"
+		fields["expiration_time"] = strconv.FormatInt(int64((*expire+time.Minute-1)/time.Minute), 10)
+	}
+	if *burn {
+		fields["burn"] = "on"
+	}
+
+	client, stop, err := torClient(ob, *timeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error connecting to Tor: %v\n", err)
+		return exitError
+	}
+	defer stop()
+
+	// The upload page sets the CSRF cookie the upload must echo
+	res, err := client.Get(u.String())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error contacting server: %v\n", err)
+		return exitError
+	}
+	res.Body.Close()
+	for _, c := range client.Jar.Cookies(u) {
+		if c.Name == "X-CSRF-Token" {
+			fields["token"] = c.Value
+		}
+	}
+
+	share, err := upload(client, u.String(), fields, files)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error uploading files: %v\n", err)
+		return exitError
+	}
+	fmt.Println(share.URL)
+	fmt.Fprintf(os.Stderr, "SHA-256: %s\n", share.SHA256)
+	return exitOK
+}
+
+// uploadResult is the server's JSON response to an upload.
+type uploadResult struct {
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+}
+
+// upload streams a multipart upload form of fields and files to serverURL.
+func upload(client *http.Client, serverURL string, fields map[string]string, files []onionbuffer.SourceFile) (*uploadResult, error) {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeUploadForm(mw, fields, files))
+	}()
+	req, err := http.NewRequest(http.MethodPost, serverURL, pr)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(res.Body, 512))
+		// Errors come as JSON since it was asked for, older servers send text
+		var apiErr struct{ Error string }
+		if json.Unmarshal(msg, &apiErr) == nil && apiErr.Error != "" {
+			msg = []byte(apiErr.Error)
+		}
+		return nil, fmt.Errorf("%s: %s", res.Status, strings.TrimSpace(string(msg)))
+	}
+	var result uploadResult
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// writeUploadForm writes the upload form's fields, then its files.
+func writeUploadForm(mw *multipart.Writer, fields map[string]string, files []onionbuffer.SourceFile) error {
+	for k, v := range fields {
+		if err := mw.WriteField(k, v); err != nil {
+			return err
+		}
+	}
+	for _, f := range files {
+		// Send the file's type so a share without an archive keeps it
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", mime.FormatMediaType("form-data", map[string]string{"name": "files", "filename": f.Name()}))
+		h.Set("Content-Type", f.ContentType())
+		part, err := mw.CreatePart(h)
+		if err != nil {
+			return err
+		}
+		r, err := f.Open()
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(part, r)
+		r.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
@@ -0,0 +1,265 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/cretz/bine/tor"
+
+	"github.com/ciehanski/onionbox/config"
+	"github.com/ciehanski/onionbox/onionbox"
+	"github.com/ciehanski/onionbox/onionstore"
+)
+
+// serve runs the onionbox server, letting anyone with its address upload
+// files to share.
+func serve(args []string) int {
+	fs := newFlagSet("serve", "[flags]", "Run the onionbox server. This is the default command.")
+	cfg, code := loadConfig(fs, args)
+	if cfg == nil {
+		return code
+	}
+	return runServer(cfg, func(obs []*onionbox.Onionbox) error {
+		for _, ob := range obs {
+			fmt.Printf("%sPlease open a Tor capable browser and navigate to http://%v.onion\n", serviceLabel(ob), ob.OnionURL)
+		}
+		return nil
+	})
+}
+
+// newFlagSet returns a flag set for a subcommand with its own help.
+func newFlagSet(name, synopsis, description string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: onionbox %s %s\n\n%s\n\nFlags:\n", name, synopsis, description)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses args into fs. It returns false along with the exit code
+// if the command should not run.
+func parseFlags(fs *flag.FlagSet, args []string) (bool, int) {
+	if err := fs.Parse(args); err == flag.ErrHelp {
+		return false, exitOK
+	} else if err != nil {
+		return false, exitUsage
+	}
+	return true, exitOK
+}
+
+// loadConfig loads and validates the server configuration from args. On
+// failure it returns a nil configuration and the exit code.
+func loadConfig(fs *flag.FlagSet, args []string) (*config.Config, int) {
+	cfg, err := config.Parse(fs, args)
+	if err == flag.ErrHelp {
+		return nil, exitOK
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		return nil, exitError
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid configuration:\n%v\n", err)
+		return nil, exitError
+	}
+	return cfg, exitOK
+}
+
+// runServer starts the configured onion services and serves them until one
+// fails or the process is told to stop, then shuts them down gracefully.
+// ready is called once the services' addresses are known, before the first
+// request is served.
+func runServer(cfg *config.Config, ready func(obs []*onionbox.Onionbox) error) int {
+	// Create onionbox instances that store config, one per onion service
+	obs, err := cfg.NewServices()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error applying configuration: %v\n", err)
+		return exitError
+	}
+	ob := obs[0]
+	if err := ob.SetupLogging(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error setting up logging: %v\n", err)
+		return exitError
+	}
+	logger := ob.Logger
+	for _, svc := range obs {
+		svc.Logger = logger
+		if svc.Name != "" {
+			svc.Logger = logger.With("service", svc.Name)
+		}
+	}
+
+	// Catch signals from the start so none kills onionbox before its
+	// buffers are wiped
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	var admin *http.Server
+	if cfg.Admin.Addr != "" {
+		admin = &http.Server{Addr: cfg.Admin.Addr, Handler: onionbox.AdminHandler(obs...), ReadHeaderTimeout: ob.ReadHeaderTimeout}
+		go func() {
+			if err := admin.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logger.Error("Error serving admin interface", "err", err)
+			}
+		}()
+		defer admin.Close()
+	}
+
+	// Show Tor's progress while the services publish. A signal received
+	// meanwhile aborts startup.
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	t, onionSvcs, err := publish(ctx, obs)
+	cancel()
+	if err != nil {
+		logger.Error("Error starting Tor & initializing onion service", "err", err)
+		onionbox.ShutdownAll(context.Background(), obs...)
+		return exitError
+	}
+
+	// Create a separate go routine per store which destroys buffers as
+	// soon as they expire, until the expiry context is cancelled on
+	// shutdown.
+	expiryCtx, stopExpiry := context.WithCancel(context.Background())
+	expiring := make(map[*onionstore.OnionStore]bool)
+	for _, svc := range obs {
+		if expiring[svc.Store] {
+			continue
+		}
+		expiring[svc.Store] = true
+		go func(svc *onionbox.Onionbox) {
+			if err := svc.Store.DestroyExpiredBuffers(expiryCtx); err != nil && err != context.Canceled {
+				svc.Logger.Error("Error destroying expired buffers", "err", err)
+			}
+		}(svc)
+	}
+
+	code := exitOK
+	srvErrCh := make(chan error, len(obs))
+	if err := ready(obs); err != nil {
+		logger.Error("Error preparing onion service", "err", err)
+		code = exitError
+	} else {
+		// Begin serving
+		for i, svc := range obs {
+			go func(svc *onionbox.Onionbox, onionSvc *tor.OnionService) {
+				srvErrCh <- svc.Serve(onionSvc)
+			}(svc, onionSvcs[i])
+		}
+		select {
+		case sig := <-signals:
+			logger.Info("Shutting down, waiting for transfers to finish", "signal", sig.String(), "timeout", cfg.HTTP.ShutdownTimeout.String())
+		case err := <-srvErrCh:
+			logger.Error("Error serving on onion service", "err", err)
+			code = exitError
+		}
+	}
+
+	// Drain transfers until the shutdown timeout, or a second signal
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout.Duration)
+	defer cancelShutdown()
+	go func() {
+		select {
+		case <-signals:
+			logger.Warn("Second signal received, cutting transfers off")
+			cancelShutdown()
+		case <-shutdownCtx.Done():
+		}
+	}()
+	stopExpiry()
+	if err := onionbox.ShutdownAll(shutdownCtx, obs...); err != nil {
+		logger.Error("Error shutting down onionbox server", "err", err)
+		code = exitError
+	}
+	if admin != nil {
+		if err := admin.Shutdown(shutdownCtx); err != nil {
+			logger.Warn("Error shutting down admin interface", "err", err)
+		}
+	}
+	if err := closeTor(t, onionSvcs); err != nil {
+		logger.Error("Error closing connection to Tor", "err", err)
+		code = exitError
+	}
+	logger.Info("Shutdown complete")
+	return code
+}
+
+// publish starts Tor and publishes every onion service on it, in order.
+// Should one fail, those already published are closed along with Tor.
+func publish(ctx context.Context, obs []*onionbox.Onionbox) (*tor.Tor, []*tor.OnionService, error) {
+	t, err := obs[0].StartTor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	onionSvcs := make([]*tor.OnionService, 0, len(obs))
+	for _, ob := range obs {
+		ob.OnBootstrap = bootstrapPrinter()
+		onionSvc, err := ob.Publish(ctx, t)
+		if err != nil {
+			if ob.Name != "" {
+				err = fmt.Errorf("%s: %w", ob.Name, err)
+			}
+			return nil, nil, errors.Join(err, closeTor(t, onionSvcs))
+		}
+		onionSvcs = append(onionSvcs, onionSvc)
+	}
+	return t, onionSvcs, nil
+}
+
+// closeTor removes the onion services and then closes Tor.
+func closeTor(t *tor.Tor, onionSvcs []*tor.OnionService) error {
+	var errs []error
+	for _, onionSvc := range onionSvcs {
+		if err := onionSvc.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing onion service %s: %w", onionSvc.ID, err))
+		}
+	}
+	if err := t.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// serviceLabel names an onion service in messages when several run.
+func serviceLabel(ob *onionbox.Onionbox) string {
+	if ob.Name == "" {
+		return ""
+	}
+	return ob.Name + ": "
+}
+
+// bootstrapPrinter returns an OnBootstrap hook reporting Tor's startup
+// progress on the terminal, along with every new problem diagnosed.
+func bootstrapPrinter() func(onionbox.BootstrapStatus) {
+	var problem string
+	var published bool
+	return func(status onionbox.BootstrapStatus) {
+		if published {
+			return
+		}
+		if status.Problem != problem {
+			problem = status.Problem
+			fmt.Fprintf(os.Stderr, "\nWarning: %s\n", problem)
+		}
+		if status.Published {
+			published = true
+			fmt.Fprintf(os.Stderr, "\r%-60s\n", "Onion service published.")
+			return
+		}
+		fmt.Fprintf(os.Stderr, "\rBootstrapping Tor: %3d%% %-40.40s", status.Progress, status.Summary)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionbox"
+	"github.com/ciehanski/onionbox/onionbuffer"
+)
+
+// share serves local files as a single share on a new onion service.
+func share(args []string) int {
+	fs := newFlagSet("share", "[flags] <file>...", "Serve local files as a share and print its address.")
+	archive := fs.String("archive", string(onionbuffer.ArchiveZip), "archive format: zip, tar.gz, tar.zst or none (single file only)")
+	password := passwordFlag(fs, "password protecting the share")
+	downloads := fs.Int64("downloads", 0, "number of downloads allowed (0 for unlimited)")
+	expire := fs.Duration("expire", 0, "destroy the share after this long, such as 1h (0 to keep it)")
+	burn := fs.Bool("burn", false, "destroy the share after its first successful download")
+	cfg, code := loadConfig(fs, args)
+	if cfg == nil {
+		return code
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	format, err := onionbuffer.ParseArchiveFormat(*archive)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid archive format %q\n", *archive)
+		return exitUsage
+	}
+	var files []onionbuffer.SourceFile
+	for _, path := range fs.Args() {
+		f, err := onionbuffer.LocalFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading file: %v\n", err)
+			return exitError
+		}
+		files = append(files, f)
+	}
+	// Build the share before starting Tor so mistakes are reported quickly
+	oBuffer, err := onionbuffer.NewArchive(format, files)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error archiving files: %v\n", err)
+		return exitError
+	}
+	oBuffer.Name = onionbox.NewShareName()
+	if *password != "" {
+		if err := oBuffer.SetPassword(*password); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encrypting share: %v\n", err)
+			return exitError
+		}
+	}
+	oBuffer.DownloadLimit = *downloads
+	oBuffer.BurnAfterRead = *burn
+	if *expire > 0 {
+		if err := oBuffer.SetDeadline(time.Now().Add(*expire)); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid expiry: %v\n", err)
+			return exitUsage
+		}
+	}
+
+	return runServer(cfg, func(obs []*onionbox.Onionbox) error {
+		ob := firstServing(obs, onionbox.RouteDownload)
+		if ob == nil {
+			return errors.New("no onion service serves downloads")
+		}
+		if err := ob.Store.Add(oBuffer); err != nil {
+			return err
+		}
+		fmt.Printf("Share available at http://%s.onion/%s\n", ob.OnionURL, oBuffer.Name)
+		fmt.Printf("SHA-256: %s\n", oBuffer.Checksum)
+		return nil
+	})
+}
+
+// receive runs the server for someone else to upload files to, printing the
+// address of every share they upload so it can be downloaded.
+func receive(args []string) int {
+	fs := newFlagSet("receive", "[flags]", "Run the server for someone to upload files to you. Send them the printed\naddress; the address of every share they upload is printed as it arrives.")
+	cfg, code := loadConfig(fs, args)
+	if cfg == nil {
+		return code
+	}
+	return runServer(cfg, func(obs []*onionbox.Onionbox) error {
+		if firstServing(obs, onionbox.RouteUpload) == nil {
+			return errors.New("no onion service serves uploads")
+		}
+		for _, ob := range obs {
+			if !ob.Serves(onionbox.RouteUpload) {
+				continue
+			}
+			ob.OnShare = func(url string, b *onionbuffer.OnionBuffer) {
+				fmt.Printf("Received share %s (SHA-256 %s)\n", url, b.Checksum)
+			}
+			fmt.Printf("%sSend this address to the sender: http://%v.onion\n", serviceLabel(ob), ob.OnionURL)
+		}
+		return nil
+	})
+}
+
+// firstServing returns the first onion service serving route, or nil.
+func firstServing(obs []*onionbox.Onionbox, route onionbox.Route) *onionbox.Onionbox {
+	for _, ob := range obs {
+		if ob.Serves(route) {
+			return ob
+		}
+	}
+	return nil
+}
+
+// passwordFlag defines a -password flag defaulting to the ONIONBOX_PASSWORD
+// environment variable, which keeps the password out of the process list.
+func passwordFlag(fs *flag.FlagSet, usage string) *string {
+	return fs.String("password", os.Getenv("ONIONBOX_PASSWORD"), usage+" (default $ONIONBOX_PASSWORD)")
+}
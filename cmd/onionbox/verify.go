@@ -0,0 +1,95 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/cretz/bine/torutil"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+)
+
+// verify checks a downloaded share manifest against its signature and,
+// optionally, the downloaded archive against the manifest's checksum.
+// It returns the process exit code.
+func verify(args []string) int {
+	fs := newFlagSet("verify", "(-key <pubkey.pem> | -onion <address>) [-sig <manifest.sig>] [-file <share>] <manifest.json>",
+		"Verify a downloaded share manifest against its signature and, optionally,\na downloaded share against the manifest's checksum.")
+	keyPath := fs.String("key", "", "PEM encoded ed25519 public key of the operator")
+	onion := fs.String("onion", "", "v3 onion address whose identity key signed the manifest")
+	sigPath := fs.String("sig", "", "manifest signature (default: manifest path with .sig extension)")
+	archive := fs.String("file", "", "downloaded share to check against the manifest checksum")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	if fs.NArg() != 1 || (*keyPath == "") == (*onion == "") {
+		fs.Usage()
+		return exitUsage
+	}
+
+	manifestPath := fs.Arg(0)
+	if *sigPath == "" {
+		*sigPath = strings.TrimSuffix(manifestPath, ".json") + ".sig"
+	}
+
+	var pub ed25519.PublicKey
+	if *keyPath != "" {
+		key, err := onionbuffer.LoadVerifyKey(*keyPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading public key: %v\n", err)
+			return exitError
+		}
+		pub = key
+	} else {
+		id := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(*onion, "http://"), "https://"), "/")
+		key, err := torutil.PublicKeyFromV3OnionServiceID(strings.TrimSuffix(id, ".onion"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading onion address: %v\n", err)
+			return exitError
+		}
+		pub = ed25519.PublicKey(key)
+	}
+
+	data, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading manifest: %v\n", err)
+		return exitError
+	}
+	sig, err := ioutil.ReadFile(*sigPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading signature: %v\n", err)
+		return exitError
+	}
+	m, err := onionbuffer.VerifyManifest(data, sig, pub)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Manifest NOT verified: %v\n", err)
+		return exitError
+	}
+	fmt.Printf("Manifest signature OK for share %s (created %s)\n", m.ShareID, m.CreatedAt)
+
+	if *archive != "" {
+		f, err := os.Open(*archive)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening share: %v\n", err)
+			return exitError
+		}
+		defer f.Close()
+		hash := sha256.New()
+		if _, err := io.Copy(hash, f); err != nil {
+			fmt.Fprintf(os.Stderr, "Error hashing share: %v\n", err)
+			return exitError
+		}
+		if sum := hex.EncodeToString(hash.Sum(nil)); sum != m.Checksum {
+			fmt.Fprintf(os.Stderr, "Share checksum MISMATCH: got %s, manifest lists %s\n", sum, m.Checksum)
+			return exitError
+		}
+		fmt.Printf("Share checksum OK: %s\n", m.Checksum)
+	}
+	return exitOK
+}
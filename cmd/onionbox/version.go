@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/ciehanski/onionbox/onionbox"
+)
+
+// versionCmd prints onionbox's version and those of its Go and Tor builds.
+func versionCmd(args []string) int {
+	fs := newFlagSet("version", "", "Print version information.")
+	if ok, code := parseFlags(fs, args); !ok {
+		return code
+	}
+	fmt.Printf("onionbox %s\n", version)
+	fmt.Printf("Go %s %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	if v := onionbox.EmbeddedTorVersion(); v != "" {
+		fmt.Printf("Tor %s (embedded)\n", v)
+	} else {
+		fmt.Println("Tor not embedded, use an external Tor with -tor-control")
+	}
+	return exitOK
+}
@@ -0,0 +1,833 @@
+// Package config loads onionbox's configuration from a TOML or YAML file,
+// environment variables and command line flags, in increasing order of
+// precedence.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v2"
+
+	"github.com/ciehanski/onionbox/onionbox"
+	"github.com/ciehanski/onionbox/onionbuffer"
+	"github.com/ciehanski/onionbox/onionstore"
+)
+
+// EnvPrefix prefixes every environment variable override. A setting's
+// variable is the prefix followed by its section and key in upper case,
+// such as ONIONBOX_TOR_LOCAL_PORT for local_port in the [tor] section.
+const EnvPrefix = "ONIONBOX_"
+
+// Config is onionbox's complete configuration.
+type Config struct {
+	Debug   bool    `toml:"debug" yaml:"debug"`
+	Tor     Tor     `toml:"tor" yaml:"tor"`
+	DoS     DoS     `toml:"dos" yaml:"dos"`
+	Limits  Limits  `toml:"limits" yaml:"limits"`
+	Log     Log     `toml:"log" yaml:"log"`
+	HTTP    HTTP    `toml:"http" yaml:"http"`
+	Admin   Admin   `toml:"admin" yaml:"admin"`
+	Signing Signing `toml:"signing" yaml:"signing"`
+	Shares  Shares  `toml:"shares" yaml:"shares"`
+	UI      UI      `toml:"ui" yaml:"ui"`
+
+	// Services, if any, are published instead of the single onion service
+	// the top level settings describe, all on the same Tor instance.
+	Services []Service `toml:"services" yaml:"services"`
+}
+
+// Tor configures the onion service.
+type Tor struct {
+	Version3   bool   `toml:"version3" yaml:"version3"`
+	RemotePort int    `toml:"remote_port" yaml:"remote_port"`
+	LocalPort  int    `toml:"local_port" yaml:"local_port"`
+	Torrc      string `toml:"torrc" yaml:"torrc"`
+	// PublishTimeout bounds how long Tor may take to bootstrap and publish
+	// the onion service.
+	PublishTimeout Duration `toml:"publish_timeout" yaml:"publish_timeout"`
+	// Bridges are bridge lines used instead of public relays. Transports
+	// maps the pluggable transports they use to their plugin binaries.
+	Bridges    []string          `toml:"bridges" yaml:"bridges"`
+	Transports map[string]string `toml:"transports" yaml:"transports"`
+	// ControlAddr, if set, uses an already running Tor through its control
+	// port (host:port, or unix:path for a socket) instead of the embedded
+	// one. ControlPassword is needed unless Tor offers cookie auth.
+	ControlAddr     string `toml:"control_addr" yaml:"control_addr"`
+	ControlPassword string `toml:"control_password" yaml:"control_password"`
+	// ProxyProtocol publishes the onion services exporting circuit IDs in
+	// PROXY protocol headers, so limits apply per circuit. Connections
+	// without a header are dropped. ADD_ONION cannot export them, so the
+	// services are configured as HiddenServiceDir services instead, which
+	// hands their onion keys to Tor through a file on a tmpfs, wiped as
+	// soon as Tor has loaded it.
+	ProxyProtocol bool `toml:"proxy_protocol" yaml:"proxy_protocol"`
+}
+
+// DoS configures Tor's defenses against floods of the onion services.
+// Proof of work needs Tor 0.4.8 or later and intro point rate limiting Tor
+// 0.4.2 or later, both newer than the embedded one. Intro point rate
+// limiting hands the onion key to Tor through a file on a tmpfs.
+type DoS struct {
+	MaxStreams             int  `toml:"max_streams" yaml:"max_streams"`
+	MaxStreamsCloseCircuit bool `toml:"max_streams_close_circuit" yaml:"max_streams_close_circuit"`
+	PoW                    bool `toml:"pow" yaml:"pow"`
+	PoWQueueRate           int  `toml:"pow_queue_rate" yaml:"pow_queue_rate"`
+	PoWQueueBurst          int  `toml:"pow_queue_burst" yaml:"pow_queue_burst"`
+	IntroDoS               bool `toml:"intro_dos_defense" yaml:"intro_dos_defense"`
+	IntroDoSRatePerSec     int  `toml:"intro_dos_rate_per_sec" yaml:"intro_dos_rate_per_sec"`
+	IntroDoSBurstPerSec    int  `toml:"intro_dos_burst_per_sec" yaml:"intro_dos_burst_per_sec"`
+}
+
+// Limits configures the budgets each client gets, keyed on its Tor circuit
+// when the onion service exports circuit IDs and global otherwise. Bursts
+// default to one second's worth and zero disables a budget.
+type Limits struct {
+	RequestsPerSecond    float64 `toml:"requests_per_second" yaml:"requests_per_second"`
+	RequestBurst         int     `toml:"request_burst" yaml:"request_burst"`
+	UploadBytesPerSecond int64   `toml:"upload_bytes_per_second" yaml:"upload_bytes_per_second"`
+	UploadBurst          int64   `toml:"upload_burst" yaml:"upload_burst"`
+	Downloads            int     `toml:"downloads" yaml:"downloads"`
+}
+
+// Log configures logging.
+type Log struct {
+	Level    string `toml:"level" yaml:"level"`
+	Forensic bool   `toml:"forensic" yaml:"forensic"`
+	Sink     string `toml:"sink" yaml:"sink"`
+	File     string `toml:"file" yaml:"file"`
+	RingSize int    `toml:"ring_size" yaml:"ring_size"`
+}
+
+// HTTP configures the server's timeouts. Uploads and downloads get the read
+// or write timeout plus the time their size takes at MinThroughput bytes
+// per second. ShutdownTimeout is how long in-flight transfers may take to
+// finish on shutdown.
+type HTTP struct {
+	ReadHeaderTimeout Duration `toml:"read_header_timeout" yaml:"read_header_timeout"`
+	ReadTimeout       Duration `toml:"read_timeout" yaml:"read_timeout"`
+	WriteTimeout      Duration `toml:"write_timeout" yaml:"write_timeout"`
+	IdleTimeout       Duration `toml:"idle_timeout" yaml:"idle_timeout"`
+	MinThroughput     int64    `toml:"min_throughput" yaml:"min_throughput"`
+	ShutdownTimeout   Duration `toml:"shutdown_timeout" yaml:"shutdown_timeout"`
+}
+
+// Admin configures the loopback admin interface.
+type Admin struct {
+	Addr string `toml:"addr" yaml:"addr"`
+}
+
+// Signing configures share manifest signing.
+type Signing struct {
+	Key      string `toml:"key" yaml:"key"`
+	OnionKey bool   `toml:"onion_key" yaml:"onion_key"`
+}
+
+// Shares configures limits and defaults applied to shares.
+type Shares struct {
+	FileDownloads        string   `toml:"file_downloads" yaml:"file_downloads"`
+	FailedDownloads      string   `toml:"failed_downloads" yaml:"failed_downloads"`
+	DefaultExpiry        Duration `toml:"default_expiry" yaml:"default_expiry"`
+	DefaultDownloadLimit int64    `toml:"default_download_limit" yaml:"default_download_limit"`
+	MaxUploadSize        int64    `toml:"max_upload_size" yaml:"max_upload_size"`
+	Indistinguishable    bool     `toml:"indistinguishable" yaml:"indistinguishable"`
+	MemoryQuota          int64    `toml:"memory_quota" yaml:"memory_quota"`
+}
+
+// UI configures text shown to users.
+type UI struct {
+	Banner string `toml:"banner" yaml:"banner"`
+}
+
+// Service configures one of several onion services. Settings it leaves out
+// fall back on the top level ones, except LocalPort which is picked at
+// random.
+type Service struct {
+	// Name identifies the service in logs and in other services' ShareVia.
+	Name string `toml:"name" yaml:"name"`
+	// Routes are the endpoints served: upload, download and site. Without
+	// any the service serves upload and download.
+	Routes  []string `toml:"routes" yaml:"routes"`
+	SiteDir string   `toml:"site_dir" yaml:"site_dir"`
+	// Store names the namespace shares are kept in, the service's name by
+	// default. Services naming the same store see the same shares.
+	Store string `toml:"store" yaml:"store"`
+	// ShareVia names the service whose address links shares uploaded here.
+	ShareVia   string `toml:"share_via" yaml:"share_via"`
+	RemotePort int    `toml:"remote_port" yaml:"remote_port"`
+	LocalPort  int    `toml:"local_port" yaml:"local_port"`
+	// ClientAuth holds the x25519 public keys of the only clients allowed
+	// to reach the service.
+	ClientAuth []string `toml:"client_auth" yaml:"client_auth"`
+	// Key is a PEM encoded ed25519 private key, such as one written by
+	// onionbox keygen, giving the service a persistent address.
+	Key           string `toml:"key" yaml:"key"`
+	MaxUploadSize int64  `toml:"max_upload_size" yaml:"max_upload_size"`
+	MemoryQuota   int64  `toml:"memory_quota" yaml:"memory_quota"`
+}
+
+// store returns the namespace the service keeps shares in.
+func (s *Service) store() string {
+	if s.Store != "" {
+		return s.Store
+	}
+	return s.Name
+}
+
+// routes returns the service's routes.
+func (s *Service) routes() []onionbox.Route {
+	routes := make([]onionbox.Route, len(s.Routes))
+	for i, r := range s.Routes {
+		routes[i] = onionbox.Route(r)
+	}
+	return routes
+}
+
+// Duration is a time.Duration written as a string such as "90m".
+type Duration struct {
+	time.Duration
+}
+
+// UnmarshalText parses a duration for TOML files and environment variables.
+func (d *Duration) UnmarshalText(text []byte) error {
+	v, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	d.Duration = v
+	return nil
+}
+
+// UnmarshalYAML parses a duration for YAML files.
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	return d.UnmarshalText([]byte(s))
+}
+
+// Default returns the configuration used when nothing overrides it.
+func Default() *Config {
+	return &Config{
+		Tor: Tor{
+			Version3:       true,
+			RemotePort:     80,
+			PublishTimeout: Duration{onionbox.DefaultPublishTimeout},
+		},
+		Log: Log{
+			Level:    "info",
+			Sink:     string(onionbox.LogSinkStderr),
+			RingSize: onionbox.DefaultLogRingSize,
+		},
+		HTTP: HTTP{
+			ReadHeaderTimeout: Duration{onionbox.DefaultReadHeaderTimeout},
+			ReadTimeout:       Duration{onionbox.DefaultReadTimeout},
+			WriteTimeout:      Duration{onionbox.DefaultWriteTimeout},
+			IdleTimeout:       Duration{onionbox.DefaultIdleTimeout},
+			MinThroughput:     onionbox.DefaultMinThroughput,
+			ShutdownTimeout:   Duration{onionbox.DefaultShutdownTimeout},
+		},
+		Shares: Shares{
+			FileDownloads:   string(onionbox.FileDownloadsCount),
+			FailedDownloads: string(onionbox.FailedDownloadsKeep),
+		},
+	}
+}
+
+// Load reads the configuration file at path, if any, on top of the
+// defaults and then applies environment variable overrides. The file's
+// format is chosen by its extension: .toml, or .yaml and .yml.
+func Load(path string) (*Config, error) {
+	c := Default()
+	if path != "" {
+		if err := c.loadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := c.loadEnv(os.LookupEnv); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".toml":
+		md, err := toml.DecodeFile(path, c)
+		if err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+		if undecoded := md.Undecoded(); len(undecoded) > 0 {
+			keys := make([]string, len(undecoded))
+			for i, key := range undecoded {
+				keys[i] = key.String()
+			}
+			return fmt.Errorf("%s: unknown settings %s", path, strings.Join(keys, ", "))
+		}
+	case ".yaml", ".yml":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := yaml.UnmarshalStrict(data, c); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	default:
+		return fmt.Errorf("%s: unsupported config format %q, use .toml or .yaml", path, ext)
+	}
+	return nil
+}
+
+// loadEnv applies every environment variable override found by lookup.
+func (c *Config) loadEnv(lookup func(string) (string, bool)) error {
+	return walk(reflect.ValueOf(c).Elem(), EnvPrefix, func(name string, v reflect.Value) error {
+		s, ok := lookup(name)
+		if !ok {
+			return nil
+		}
+		if err := setValue(v, s); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+		return nil
+	})
+}
+
+// walk calls fn on every setting in v, a struct, with the name of its
+// environment variable.
+func walk(v reflect.Value, prefix string, fn func(name string, v reflect.Value) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		name := prefix + strings.ToUpper(t.Field(i).Tag.Get("toml"))
+		if field.Kind() == reflect.Struct && field.Type() != reflect.TypeOf(Duration{}) {
+			if err := walk(field, name+"_", fn); err != nil {
+				return err
+			}
+			continue
+		}
+		// Lists of tables such as services can only be set in a file
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Struct {
+			continue
+		}
+		if err := fn(name, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setValue(v reflect.Value, s string) error {
+	if d, ok := v.Addr().Interface().(*Duration); ok {
+		return d.UnmarshalText([]byte(s))
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported setting type %s", v.Type())
+		}
+		// Lists are separated by semicolons or newlines, since bridge
+		// lines hold spaces and commas
+		var list []string
+		for _, item := range strings.FieldsFunc(s, func(r rune) bool { return r == ';' || r == '\n' }) {
+			if item = strings.TrimSpace(item); item != "" {
+				list = append(list, item)
+			}
+		}
+		v.Set(reflect.ValueOf(list))
+	case reflect.Map:
+		// Maps are comma separated key=value pairs
+		m := map[string]string{}
+		for _, pair := range strings.Split(s, ",") {
+			if strings.TrimSpace(pair) == "" {
+				continue
+			}
+			key, value, ok := strings.Cut(pair, "=")
+			if !ok {
+				return fmt.Errorf("%q is not a key=value pair", pair)
+			}
+			m[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		v.Set(reflect.ValueOf(m))
+	default:
+		return fmt.Errorf("unsupported setting type %s", v.Type())
+	}
+	return nil
+}
+
+// Validate checks every setting, returning all problems found at once.
+func (c *Config) Validate() error {
+	var errs []error
+	invalid := func(setting, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", setting, fmt.Sprintf(format, args...)))
+	}
+
+	if c.Tor.RemotePort < 1 || c.Tor.RemotePort > 65535 {
+		invalid("tor.remote_port", "must be between 1 and 65535, got %d", c.Tor.RemotePort)
+	}
+	if c.Tor.LocalPort < 0 || c.Tor.LocalPort > 65535 {
+		invalid("tor.local_port", "must be between 0 and 65535, got %d", c.Tor.LocalPort)
+	}
+	if c.Tor.Torrc != "" {
+		if _, err := os.Stat(c.Tor.Torrc); err != nil {
+			invalid("tor.torrc", "%v", err)
+		}
+	}
+
+	if c.Tor.PublishTimeout.Duration < 0 {
+		invalid("tor.publish_timeout", "must not be negative, got %s", c.Tor.PublishTimeout)
+	}
+
+	if c.Tor.ControlAddr != "" {
+		if c.Tor.Torrc != "" {
+			invalid("tor.torrc", "only applies to the embedded Tor, configure the external Tor's own torrc instead")
+		}
+		if len(c.Tor.Bridges) > 0 {
+			invalid("tor.bridges", "only apply to the embedded Tor, configure the external Tor's own torrc instead")
+		}
+		if !strings.HasPrefix(c.Tor.ControlAddr, "unix:") {
+			if _, _, err := net.SplitHostPort(c.Tor.ControlAddr); err != nil {
+				invalid("tor.control_addr", "must be host:port or unix:path, got %q", c.Tor.ControlAddr)
+			}
+		}
+	}
+	if c.Tor.ProxyProtocol {
+		if !c.Tor.Version3 {
+			invalid("tor.proxy_protocol", "needs tor.version3")
+		}
+		if c.Tor.ControlAddr == "" {
+			invalid("tor.proxy_protocol", "needs Tor 0.4.0 or later, use one through tor.control_addr")
+		}
+	}
+	for i, line := range c.Tor.Bridges {
+		b, err := onionbox.ParseBridgeLine(line)
+		if err != nil {
+			invalid(fmt.Sprintf("tor.bridges[%d]", i), "%v", err)
+			continue
+		}
+		if _, ok := c.Tor.Transports[b.Transport]; b.Transport != "" && !ok {
+			invalid(fmt.Sprintf("tor.bridges[%d]", i), "transport %s needs a plugin in tor.transports", b.Transport)
+		}
+	}
+	for _, name := range sortedKeys(c.Tor.Transports) {
+		if err := onionbox.ValidateTransportPlugin(c.Tor.Transports[name]); err != nil {
+			invalid("tor.transports."+name, "%v", err)
+		}
+	}
+
+	if c.DoS.MaxStreams < 0 || c.DoS.MaxStreams > 65535 {
+		invalid("dos.max_streams", "must be between 0 and 65535, got %d", c.DoS.MaxStreams)
+	}
+	if c.DoS.MaxStreamsCloseCircuit && c.DoS.MaxStreams == 0 {
+		invalid("dos.max_streams_close_circuit", "needs dos.max_streams to be set")
+	}
+	if c.DoS.PoW {
+		if !c.Tor.Version3 {
+			invalid("dos.pow", "needs tor.version3")
+		}
+		if c.Tor.ControlAddr == "" {
+			invalid("dos.pow", "needs Tor 0.4.8 or later, use one through tor.control_addr")
+		}
+	}
+	for _, queue := range []struct {
+		name  string
+		value int
+	}{
+		{"dos.pow_queue_rate", c.DoS.PoWQueueRate},
+		{"dos.pow_queue_burst", c.DoS.PoWQueueBurst},
+	} {
+		if queue.value < 0 {
+			invalid(queue.name, "must not be negative, got %d", queue.value)
+		} else if queue.value > 0 && !c.DoS.PoW {
+			invalid(queue.name, "only applies with dos.pow")
+		}
+	}
+	if c.DoS.IntroDoS {
+		if !c.Tor.Version3 {
+			invalid("dos.intro_dos_defense", "needs tor.version3")
+		}
+		if c.Tor.ControlAddr == "" {
+			invalid("dos.intro_dos_defense", "needs Tor 0.4.2 or later, use one through tor.control_addr")
+		}
+	}
+	for _, limit := range []struct {
+		name  string
+		value int
+	}{
+		{"dos.intro_dos_rate_per_sec", c.DoS.IntroDoSRatePerSec},
+		{"dos.intro_dos_burst_per_sec", c.DoS.IntroDoSBurstPerSec},
+	} {
+		if limit.value < 0 {
+			invalid(limit.name, "must not be negative, got %d", limit.value)
+		} else if limit.value > 0 && !c.DoS.IntroDoS {
+			invalid(limit.name, "only applies with dos.intro_dos_defense")
+		}
+	}
+	if c.DoS.IntroDoSBurstPerSec > 0 && c.DoS.IntroDoSRatePerSec > c.DoS.IntroDoSBurstPerSec {
+		invalid("dos.intro_dos_burst_per_sec", "must be at least dos.intro_dos_rate_per_sec, got %d", c.DoS.IntroDoSBurstPerSec)
+	}
+
+	if c.Limits.RequestsPerSecond < 0 {
+		invalid("limits.requests_per_second", "must not be negative, got %g", c.Limits.RequestsPerSecond)
+	}
+	for _, limit := range []struct {
+		name  string
+		value int64
+	}{
+		{"limits.request_burst", int64(c.Limits.RequestBurst)},
+		{"limits.upload_bytes_per_second", c.Limits.UploadBytesPerSecond},
+		{"limits.upload_burst", c.Limits.UploadBurst},
+		{"limits.downloads", int64(c.Limits.Downloads)},
+	} {
+		if limit.value < 0 {
+			invalid(limit.name, "must not be negative, got %d", limit.value)
+		}
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		invalid("log.level", "must be debug, info, warn or error, got %q", c.Log.Level)
+	}
+	sink, err := onionbox.ParseLogSink(c.Log.Sink)
+	if err != nil {
+		invalid("log.sink", "must be none, stderr, ring, syslog or file, got %q", c.Log.Sink)
+	}
+	if sink == onionbox.LogSinkFile && c.Log.File == "" {
+		invalid("log.file", "must be set when log.sink is file")
+	}
+	if sink == onionbox.LogSinkRing && c.Log.RingSize < 1 {
+		invalid("log.ring_size", "must be positive, got %d", c.Log.RingSize)
+	}
+
+	for _, timeout := range []struct {
+		name string
+		d    Duration
+	}{
+		{"http.read_header_timeout", c.HTTP.ReadHeaderTimeout},
+		{"http.read_timeout", c.HTTP.ReadTimeout},
+		{"http.write_timeout", c.HTTP.WriteTimeout},
+		{"http.idle_timeout", c.HTTP.IdleTimeout},
+		{"http.shutdown_timeout", c.HTTP.ShutdownTimeout},
+	} {
+		if timeout.d.Duration < 0 {
+			invalid(timeout.name, "must not be negative, got %s", timeout.d)
+		}
+	}
+	if c.HTTP.MinThroughput < 0 {
+		invalid("http.min_throughput", "must not be negative, got %d", c.HTTP.MinThroughput)
+	}
+
+	if c.Admin.Addr != "" && !isLoopback(c.Admin.Addr) {
+		invalid("admin.addr", "must be a loopback address such as 127.0.0.1:8081, got %q", c.Admin.Addr)
+	}
+
+	if c.Signing.Key != "" {
+		if _, err := onionbuffer.LoadSigningKey(c.Signing.Key); err != nil {
+			invalid("signing.key", "%v", err)
+		}
+	} else if c.Signing.OnionKey {
+		invalid("signing.onion_key", "needs signing.key to be set")
+	}
+
+	switch onionbox.FileDownloadPolicy(c.Shares.FileDownloads) {
+	case onionbox.FileDownloadsCount, onionbox.FileDownloadsFree:
+	default:
+		invalid("shares.file_downloads", "must be count or free, got %q", c.Shares.FileDownloads)
+	}
+	switch onionbox.FailedDownloadPolicy(c.Shares.FailedDownloads) {
+	case onionbox.FailedDownloadsKeep, onionbox.FailedDownloadsRelease:
+	default:
+		invalid("shares.failed_downloads", "must be keep or release, got %q", c.Shares.FailedDownloads)
+	}
+	if c.Shares.DefaultExpiry.Duration < 0 {
+		invalid("shares.default_expiry", "must not be negative, got %s", c.Shares.DefaultExpiry)
+	}
+	if c.Shares.DefaultDownloadLimit < 0 {
+		invalid("shares.default_download_limit", "must not be negative, got %d", c.Shares.DefaultDownloadLimit)
+	}
+	if c.Shares.MaxUploadSize < 0 {
+		invalid("shares.max_upload_size", "must not be negative, got %d", c.Shares.MaxUploadSize)
+	}
+	if c.Shares.MemoryQuota < 0 {
+		invalid("shares.memory_quota", "must not be negative, got %d", c.Shares.MemoryQuota)
+	}
+
+	errs = append(errs, c.validateServices()...)
+	return errors.Join(errs...)
+}
+
+// validateServices checks the services, if any, can be published together.
+func (c *Config) validateServices() []error {
+	var errs []error
+	invalid := func(setting, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", setting, fmt.Sprintf(format, args...)))
+	}
+	if len(c.Services) > 0 && c.Signing.OnionKey {
+		invalid("signing.onion_key", "cannot be shared by several services, give each its own key instead")
+	}
+
+	byName := make(map[string]*Service, len(c.Services))
+	for i := range c.Services {
+		svc := &c.Services[i]
+		if _, ok := byName[svc.Name]; ok {
+			invalid(fmt.Sprintf("services[%d].name", i), "%q is used by another service", svc.Name)
+		}
+		byName[svc.Name] = svc
+	}
+	localPorts := make(map[int]bool)
+	quotas := make(map[string]int64)
+	for i := range c.Services {
+		svc := &c.Services[i]
+		setting := func(name string) string { return fmt.Sprintf("services[%d].%s", i, name) }
+		if svc.Name == "" {
+			invalid(setting("name"), "must be set")
+		}
+		routes := svc.routes()
+		if err := onionbox.ValidateRoutes(routes); err != nil {
+			invalid(setting("routes"), "%v", err)
+		}
+		if slices.Contains(routes, onionbox.RouteSite) {
+			if fi, err := os.Stat(svc.SiteDir); svc.SiteDir == "" {
+				invalid(setting("site_dir"), "must be set for the site route")
+			} else if err != nil {
+				invalid(setting("site_dir"), "%v", err)
+			} else if !fi.IsDir() {
+				invalid(setting("site_dir"), "%s is not a directory", svc.SiteDir)
+			}
+		} else if svc.SiteDir != "" {
+			invalid(setting("site_dir"), "only applies to the site route")
+		}
+		if svc.ShareVia != "" {
+			via, ok := byName[svc.ShareVia]
+			switch {
+			case !ok:
+				invalid(setting("share_via"), "no service is named %q", svc.ShareVia)
+			case len(via.Routes) > 0 && !slices.Contains(via.routes(), onionbox.RouteDownload):
+				invalid(setting("share_via"), "service %q does not serve downloads", svc.ShareVia)
+			case via.store() != svc.store():
+				invalid(setting("share_via"), "service %q does not share store %q", svc.ShareVia, svc.store())
+			}
+		}
+		if svc.RemotePort < 0 || svc.RemotePort > 65535 {
+			invalid(setting("remote_port"), "must be between 1 and 65535, got %d", svc.RemotePort)
+		}
+		if svc.LocalPort < 0 || svc.LocalPort > 65535 {
+			invalid(setting("local_port"), "must be between 0 and 65535, got %d", svc.LocalPort)
+		} else if svc.LocalPort != 0 && localPorts[svc.LocalPort] {
+			invalid(setting("local_port"), "%d is used by another service", svc.LocalPort)
+		}
+		localPorts[svc.LocalPort] = true
+		if len(svc.ClientAuth) > 0 && !c.Tor.Version3 {
+			invalid(setting("client_auth"), "needs tor.version3")
+		} else if len(svc.ClientAuth) > 0 && c.Tor.ControlAddr == "" {
+			invalid(setting("client_auth"), "needs Tor 0.4.6 or later, use one through tor.control_addr")
+		}
+		for j, key := range svc.ClientAuth {
+			if _, err := onionbox.ParseClientAuthKey(key); err != nil {
+				invalid(fmt.Sprintf("services[%d].client_auth[%d]", i, j), "%v", err)
+			}
+		}
+		if svc.Key != "" {
+			if _, err := onionbuffer.LoadSigningKey(svc.Key); err != nil {
+				invalid(setting("key"), "%v", err)
+			}
+		}
+		if svc.MaxUploadSize < 0 {
+			invalid(setting("max_upload_size"), "must not be negative, got %d", svc.MaxUploadSize)
+		}
+		if svc.MemoryQuota < 0 {
+			invalid(setting("memory_quota"), "must not be negative, got %d", svc.MemoryQuota)
+		} else if svc.MemoryQuota > 0 {
+			if quota, ok := quotas[svc.store()]; ok && quota != svc.MemoryQuota {
+				invalid(setting("memory_quota"), "conflicts with %d set for store %q by another service", quota, svc.store())
+			}
+			quotas[svc.store()] = svc.MemoryQuota
+		}
+	}
+	return errs
+}
+
+// Apply fills ob's fields from the configuration, which must be valid.
+func (c *Config) Apply(ob *onionbox.Onionbox) error {
+	ob.Debug = c.Debug
+	ob.TorVersion3 = c.Tor.Version3
+	ob.RemotePort = c.Tor.RemotePort
+	ob.LocalPort = c.Tor.LocalPort
+	ob.TorrcFile = c.Tor.Torrc
+	ob.PublishTimeout = c.Tor.PublishTimeout.Duration
+	ob.Bridges = c.Tor.Bridges
+	ob.TransportPlugins = c.Tor.Transports
+	if c.Tor.ControlAddr != "" {
+		ob.TorBackend = &onionbox.ExternalTor{Addr: c.Tor.ControlAddr, Password: c.Tor.ControlPassword}
+	}
+	ob.ProxyProtocol = c.Tor.ProxyProtocol
+	ob.DoSDefenses = onionbox.DoSDefenses{
+		MaxStreams:             c.DoS.MaxStreams,
+		MaxStreamsCloseCircuit: c.DoS.MaxStreamsCloseCircuit,
+		PoW:                    c.DoS.PoW,
+		PoWQueueRate:           c.DoS.PoWQueueRate,
+		PoWQueueBurst:          c.DoS.PoWQueueBurst,
+		IntroDoS:               c.DoS.IntroDoS,
+		IntroDoSRatePerSec:     c.DoS.IntroDoSRatePerSec,
+		IntroDoSBurstPerSec:    c.DoS.IntroDoSBurstPerSec,
+	}
+
+	ob.RateLimits = onionbox.RateLimits{
+		Requests:     c.Limits.RequestsPerSecond,
+		RequestBurst: c.Limits.RequestBurst,
+		UploadBytes:  c.Limits.UploadBytesPerSecond,
+		UploadBurst:  c.Limits.UploadBurst,
+		Downloads:    c.Limits.Downloads,
+	}
+
+	if err := ob.LogLevel.UnmarshalText([]byte(c.Log.Level)); err != nil {
+		return err
+	}
+	if c.Debug {
+		ob.LogLevel = slog.LevelDebug
+	}
+	ob.LogForensic = c.Log.Forensic
+	ob.LogSink = onionbox.LogSink(c.Log.Sink)
+	ob.LogFile = c.Log.File
+	ob.LogRingSize = c.Log.RingSize
+
+	ob.ReadHeaderTimeout = c.HTTP.ReadHeaderTimeout.Duration
+	ob.ReadTimeout = c.HTTP.ReadTimeout.Duration
+	ob.WriteTimeout = c.HTTP.WriteTimeout.Duration
+	ob.IdleTimeout = c.HTTP.IdleTimeout.Duration
+	ob.MinThroughput = c.HTTP.MinThroughput
+
+	if c.Signing.Key != "" {
+		key, err := onionbuffer.LoadSigningKey(c.Signing.Key)
+		if err != nil {
+			return err
+		}
+		ob.SigningKey = key
+	}
+	ob.SigningKeyIsOnionKey = c.Signing.OnionKey
+
+	ob.FileDownloadPolicy = onionbox.FileDownloadPolicy(c.Shares.FileDownloads)
+	ob.FailedDownloadPolicy = onionbox.FailedDownloadPolicy(c.Shares.FailedDownloads)
+	ob.DefaultExpiry = c.Shares.DefaultExpiry.Duration
+	ob.DefaultDownloadLimit = c.Shares.DefaultDownloadLimit
+	ob.MaxUploadSize = c.Shares.MaxUploadSize
+	ob.Indistinguishable = c.Shares.Indistinguishable
+	if ob.Store != nil {
+		ob.Store.Quota = c.Shares.MemoryQuota
+	}
+	ob.Banner = c.UI.Banner
+	return nil
+}
+
+// NewServices returns the onion services to publish, each configured and
+// given the store of its namespace. Without any services configured it
+// returns the single one the top level settings describe. The
+// configuration must be valid.
+func (c *Config) NewServices() ([]*onionbox.Onionbox, error) {
+	if len(c.Services) == 0 {
+		ob := &onionbox.Onionbox{Store: onionstore.NewStore()}
+		if err := c.Apply(ob); err != nil {
+			return nil, err
+		}
+		return []*onionbox.Onionbox{ob}, nil
+	}
+
+	stores := make(map[string]*onionstore.OnionStore)
+	byName := make(map[string]*onionbox.Onionbox, len(c.Services))
+	obs := make([]*onionbox.Onionbox, len(c.Services))
+	for i, svc := range c.Services {
+		store, ok := stores[svc.store()]
+		if !ok {
+			store = onionstore.NewStore()
+			stores[svc.store()] = store
+		}
+		ob := &onionbox.Onionbox{Store: store}
+		if err := c.Apply(ob); err != nil {
+			return nil, err
+		}
+		ob.Name = svc.Name
+		ob.Routes = svc.routes()
+		ob.SiteDir = svc.SiteDir
+		ob.ClientAuth = svc.ClientAuth
+		ob.LocalPort = svc.LocalPort
+		if svc.RemotePort != 0 {
+			ob.RemotePort = svc.RemotePort
+		}
+		if svc.Key != "" {
+			key, err := onionbuffer.LoadSigningKey(svc.Key)
+			if err != nil {
+				return nil, err
+			}
+			ob.OnionKey = key
+		}
+		if svc.MaxUploadSize != 0 {
+			ob.MaxUploadSize = svc.MaxUploadSize
+		}
+		obs[i] = ob
+		byName[svc.Name] = ob
+	}
+	// Quotas and links to other services are set once every service exists
+	for i, svc := range c.Services {
+		if svc.MemoryQuota != 0 {
+			obs[i].Store.Quota = svc.MemoryQuota
+		}
+		if svc.ShareVia != "" {
+			obs[i].ShareVia = byName[svc.ShareVia]
+		}
+	}
+	return obs, nil
+}
+
+// isLoopback reports whether addr, a host:port pair, is on a loopback
+// interface.
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,383 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionbox"
+	"github.com/ciehanski/onionbox/onionstore"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := writeConfig(t, "onionbox.toml", `
+debug = true
+
+[tor]
+local_port = 8080
+
+[shares]
+default_expiry = "90m"
+memory_quota = 1048576
+indistinguishable = true
+
+[ui]
+banner = "Hello"
+`)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !c.Debug || c.Tor.LocalPort != 8080 || c.Tor.RemotePort != 80 {
+		t.Errorf("unexpected tor settings %+v", c.Tor)
+	}
+	if c.Shares.DefaultExpiry.Duration != 90*time.Minute || c.Shares.MemoryQuota != 1048576 {
+		t.Errorf("unexpected share settings %+v", c.Shares)
+	}
+	if err := c.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	ob := onionbox.Onionbox{Store: onionstore.NewStore()}
+	if err := c.Apply(&ob); err != nil {
+		t.Fatal(err)
+	}
+	if ob.LocalPort != 8080 || ob.DefaultExpiry != 90*time.Minute || ob.Store.Quota != 1048576 || !ob.Indistinguishable || ob.Banner != "Hello" {
+		t.Errorf("configuration not applied: %+v", &ob)
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := writeConfig(t, "onionbox.yaml", `
+log:
+  level: warn
+  sink: ring
+shares:
+  default_expiry: 1h
+`)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Log.Level != "warn" || c.Log.Sink != "ring" || c.Shares.DefaultExpiry.Duration != time.Hour {
+		t.Errorf("unexpected settings %+v", c)
+	}
+}
+
+func TestLoadUnknownSetting(t *testing.T) {
+	for _, path := range []string{
+		writeConfig(t, "onionbox.toml", "[tor]\nlocal_prot = 8080\n"),
+		writeConfig(t, "onionbox.yml", "tor:\n  local_prot: 8080\n"),
+	} {
+		if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "local_prot") {
+			t.Errorf("expected unknown setting error for %s, got %v", path, err)
+		}
+	}
+}
+
+func TestLoadEnv(t *testing.T) {
+	c := Default()
+	env := map[string]string{
+		"ONIONBOX_TOR_LOCAL_PORT":          "9090",
+		"ONIONBOX_LOG_FORENSIC":            "true",
+		"ONIONBOX_SHARES_DEFAULT_EXPIRY":   "30m",
+		"ONIONBOX_SHARES_FAILED_DOWNLOADS": "release",
+		"ONIONBOX_HTTP_WRITE_TIMEOUT":      "10m",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	if err := c.loadEnv(lookup); err != nil {
+		t.Fatal(err)
+	}
+	if c.Tor.LocalPort != 9090 || !c.Log.Forensic || c.Shares.DefaultExpiry.Duration != 30*time.Minute || c.Shares.FailedDownloads != "release" || c.HTTP.WriteTimeout.Duration != 10*time.Minute {
+		t.Errorf("environment not applied: %+v", c)
+	}
+
+	env = map[string]string{"ONIONBOX_TOR_LOCAL_PORT": "eighty"}
+	if err := c.loadEnv(lookup); err == nil || !strings.Contains(err.Error(), "ONIONBOX_TOR_LOCAL_PORT") {
+		t.Errorf("expected error naming the variable, got %v", err)
+	}
+}
+
+func TestParseFlagsOverrideFile(t *testing.T) {
+	path := writeConfig(t, "onionbox.toml", "[tor]\nlocal_port = 8080\nremote_port = 8000\n")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	c, err := Parse(fs, []string{"-config", path, "-lport", "7070"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Tor.LocalPort != 7070 {
+		t.Errorf("expected flag to override file, got %d", c.Tor.LocalPort)
+	}
+	if c.Tor.RemotePort != 8000 {
+		t.Errorf("expected file setting to be kept, got %d", c.Tor.RemotePort)
+	}
+}
+
+func TestBridges(t *testing.T) {
+	path := writeConfig(t, "onionbox.yaml", `
+tor:
+  bridges:
+    - obfs4 192.0.2.1:443 0123456789ABCDEF0123456789ABCDEF01234567 cert=c2VjcmV0 iat-mode=0
+    - snowflake 192.0.2.3:80
+  transports:
+    obfs4: /nonexistent/obfs4proxy
+`)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Tor.Bridges) != 2 || c.Tor.Transports["obfs4"] != "/nonexistent/obfs4proxy" {
+		t.Fatalf("unexpected tor settings %+v", c.Tor)
+	}
+	err = c.Validate()
+	for _, problem := range []string{"tor.bridges[1]: transport snowflake", "tor.transports.obfs4"} {
+		if err == nil || !strings.Contains(err.Error(), problem) {
+			t.Errorf("expected error for %s, got %v", problem, err)
+		}
+	}
+
+	env := map[string]string{
+		"ONIONBOX_TOR_BRIDGES":    "192.0.2.1:443; 192.0.2.2:443",
+		"ONIONBOX_TOR_TRANSPORTS": "obfs4=/usr/bin/obfs4proxy, snowflake=/usr/bin/snowflake-client",
+	}
+	c = Default()
+	if err := c.loadEnv(func(name string) (string, bool) { v, ok := env[name]; return v, ok }); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Tor.Bridges) != 2 || c.Tor.Bridges[1] != "192.0.2.2:443" || c.Tor.Transports["snowflake"] != "/usr/bin/snowflake-client" {
+		t.Errorf("environment not applied: %+v", c.Tor)
+	}
+}
+
+func TestControlAddr(t *testing.T) {
+	c := Default()
+	c.Tor.ControlAddr = "unix:/run/tor/control"
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	ob := onionbox.Onionbox{}
+	if err := c.Apply(&ob); err != nil {
+		t.Fatal(err)
+	}
+	if backend, ok := ob.TorBackend.(*onionbox.ExternalTor); !ok || backend.Addr != "unix:/run/tor/control" {
+		t.Errorf("expected an external Tor backend, got %#v", ob.TorBackend)
+	}
+
+	c.Tor.ControlAddr = "9051"
+	c.Tor.Bridges = []string{"192.0.2.1:443"}
+	err := c.Validate()
+	for _, setting := range []string{"tor.control_addr", "tor.bridges"} {
+		if err == nil || !strings.Contains(err.Error(), setting) {
+			t.Errorf("expected error for %s, got %v", setting, err)
+		}
+	}
+
+	c = Default()
+	c.Tor.ProxyProtocol = true
+	if err := c.Validate(); err == nil || !strings.Contains(err.Error(), "tor.proxy_protocol") {
+		t.Errorf("expected the embedded Tor to be refused with tor.proxy_protocol, got %v", err)
+	}
+	c.Tor.ControlAddr = "127.0.0.1:9051"
+	if err := c.Validate(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestServices(t *testing.T) {
+	site := t.TempDir()
+	path := writeConfig(t, "onionbox.toml", `
+[tor]
+control_addr = "127.0.0.1:9051"
+
+[shares]
+max_upload_size = 1024
+
+[[services]]
+name = "inbox"
+routes = ["upload"]
+store = "shared"
+share_via = "outbox"
+memory_quota = 4096
+
+[[services]]
+name = "outbox"
+routes = ["download"]
+store = "shared"
+remote_port = 8080
+client_auth = ["descriptor:x25519:G7UEEDWZWBTGPGH6MNUZQKBH4CZNWWQOFCSFIEFSCQPXK6AGBBEA"]
+
+[[services]]
+name = "www"
+routes = ["site"]
+site_dir = "`+site+`"
+max_upload_size = 1
+`)
+	c, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	obs, err := c.NewServices()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(obs) != 3 {
+		t.Fatalf("expected 3 services, got %d", len(obs))
+	}
+	inbox, outbox, www := obs[0], obs[1], obs[2]
+	if inbox.Store != outbox.Store || inbox.Store == www.Store {
+		t.Error("expected inbox and outbox to share a store apart from www")
+	}
+	if inbox.Store.Quota != 4096 || www.Store.Quota != 0 {
+		t.Errorf("unexpected quotas %d and %d", inbox.Store.Quota, www.Store.Quota)
+	}
+	if inbox.ShareVia != outbox || outbox.RemotePort != 8080 || inbox.RemotePort != 80 {
+		t.Errorf("unexpected services %+v and %+v", inbox, outbox)
+	}
+	if inbox.MaxUploadSize != 1024 || www.MaxUploadSize != 1 || www.SiteDir != site {
+		t.Errorf("unexpected service settings %+v", www)
+	}
+	if !outbox.Serves(onionbox.RouteDownload) || outbox.Serves(onionbox.RouteUpload) || len(outbox.ClientAuth) != 1 {
+		t.Errorf("unexpected outbox %+v", outbox)
+	}
+
+	c.Services[0].ShareVia = "www"
+	c.Services[1].LocalPort = 9000
+	c.Services[2].LocalPort = 9000
+	c.Services[2].Routes = []string{"site", "upload"}
+	c.Services = append(c.Services, Service{Name: "inbox", ClientAuth: []string{"nope"}, Store: "shared", MemoryQuota: 1})
+	err = c.Validate()
+	if err == nil {
+		t.Fatal("expected invalid services")
+	}
+	for _, setting := range []string{"services[0].share_via", "services[2].local_port", "services[2].routes", "services[3].name", "services[3].client_auth[0]", "services[3].memory_quota"} {
+		if !strings.Contains(err.Error(), setting) {
+			t.Errorf("expected error for %s, got %v", setting, err)
+		}
+	}
+
+	// Without services the top level settings describe a single one
+	obs, err = Default().NewServices()
+	if err != nil || len(obs) != 1 || obs[0].Store == nil || obs[0].RemotePort != 80 {
+		t.Errorf("expected a single default service, got %v and %v", obs, err)
+	}
+}
+
+func TestDoS(t *testing.T) {
+	c, err := Load(writeConfig(t, "onionbox.yaml", `
+tor:
+  control_addr: 127.0.0.1:9051
+dos:
+  max_streams: 10
+  max_streams_close_circuit: true
+  pow: true
+  pow_queue_rate: 50
+  intro_dos_defense: true
+  intro_dos_rate_per_sec: 25
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	var ob onionbox.Onionbox
+	if err := c.Apply(&ob); err != nil {
+		t.Fatal(err)
+	}
+	want := onionbox.DoSDefenses{MaxStreams: 10, MaxStreamsCloseCircuit: true, PoW: true, PoWQueueRate: 50, IntroDoS: true, IntroDoSRatePerSec: 25}
+	if ob.DoSDefenses != want {
+		t.Errorf("expected %+v, got %+v", want, ob.DoSDefenses)
+	}
+
+	c = Default()
+	c.DoS = DoS{MaxStreams: 70000, PoW: true, PoWQueueBurst: -1, IntroDoS: true, IntroDoSRatePerSec: 50, IntroDoSBurstPerSec: 10}
+	err = c.Validate()
+	if err == nil {
+		t.Fatal("expected invalid DoS defenses")
+	}
+	for _, setting := range []string{"dos.max_streams", "dos.pow", "dos.pow_queue_burst", "dos.intro_dos_defense", "dos.intro_dos_burst_per_sec"} {
+		if !strings.Contains(err.Error(), setting) {
+			t.Errorf("expected error for %s, got %v", setting, err)
+		}
+	}
+	c.DoS = DoS{MaxStreamsCloseCircuit: true, PoWQueueRate: 5, IntroDoSRatePerSec: 5}
+	err = c.Validate()
+	for _, setting := range []string{"dos.max_streams_close_circuit", "dos.pow_queue_rate", "dos.intro_dos_rate_per_sec"} {
+		if err == nil || !strings.Contains(err.Error(), setting) {
+			t.Errorf("expected error for %s, got %v", setting, err)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	c := Default()
+	env := map[string]string{
+		"ONIONBOX_LIMITS_REQUESTS_PER_SECOND":     "0.5",
+		"ONIONBOX_LIMITS_UPLOAD_BYTES_PER_SECOND": "65536",
+		"ONIONBOX_LIMITS_DOWNLOADS":               "2",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	if err := c.loadEnv(lookup); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	var ob onionbox.Onionbox
+	if err := c.Apply(&ob); err != nil {
+		t.Fatal(err)
+	}
+	want := onionbox.RateLimits{Requests: 0.5, UploadBytes: 65536, Downloads: 2}
+	if ob.RateLimits != want {
+		t.Errorf("expected %+v, got %+v", want, ob.RateLimits)
+	}
+
+	c.Limits = Limits{RequestsPerSecond: -1, UploadBurst: -1, Downloads: -1}
+	err := c.Validate()
+	for _, setting := range []string{"limits.requests_per_second", "limits.upload_burst", "limits.downloads"} {
+		if err == nil || !strings.Contains(err.Error(), setting) {
+			t.Errorf("expected error for %s, got %v", setting, err)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	c := Default()
+	c.Tor.RemotePort = 0
+	c.Log.Sink = "file"
+	c.Admin.Addr = "0.0.0.0:8081"
+	c.Shares.FileDownloads = "sometimes"
+	c.HTTP.ReadTimeout = Duration{-time.Second}
+	err := c.Validate()
+	if err == nil {
+		t.Fatal("expected invalid configuration")
+	}
+	for _, setting := range []string{"tor.remote_port", "log.file", "admin.addr", "shares.file_downloads", "http.read_timeout"} {
+		if !strings.Contains(err.Error(), setting) {
+			t.Errorf("expected error for %s, got %v", setting, err)
+		}
+	}
+	if err := Default().Validate(); err != nil {
+		t.Errorf("expected default configuration to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"flag"
+	"os"
+)
+
+// ConfigFlag returns the path of the configuration file given by the -config
+// flag, falling back on the ONIONBOX_CONFIG environment variable.
+func ConfigFlag(fs *flag.FlagSet) *string {
+	return fs.String("config", os.Getenv(EnvPrefix+"CONFIG"), "path of a TOML or YAML configuration file")
+}
+
+// Flags binds command line flags overriding the settings of c to fs. Each
+// flag defaults to the setting's current value.
+func (c *Config) Flags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.Debug, "debug", c.Debug, "run in debug mode")
+	fs.BoolVar(&c.Tor.Version3, "torv3", c.Tor.Version3, "use version 3 of the Tor circuit (recommended)")
+	fs.IntVar(&c.Tor.RemotePort, "rport", c.Tor.RemotePort, "remote port used to host the onion service")
+	fs.IntVar(&c.Tor.LocalPort, "lport", c.Tor.LocalPort, "local port used to host the onion service")
+	fs.StringVar(&c.Tor.Torrc, "torrc", c.Tor.Torrc, "provide a custom torrc file for the onion service")
+	fs.StringVar(&c.Tor.ControlAddr, "tor-control", c.Tor.ControlAddr, "use a running Tor through its control port (host:port or unix:path) instead of the embedded one")
+	fs.StringVar(&c.Log.Level, "log-level", c.Log.Level, "minimum level of logged records: debug, info, warn or error")
+	fs.BoolVar(&c.Log.Forensic, "log-forensic", c.Log.Forensic, "log share IDs, file names, user agents and circuits in the clear instead of redacting them")
+	fs.StringVar(&c.Log.Sink, "log-sink", c.Log.Sink, "where to write logs: none, stderr, ring, syslog or file")
+	fs.StringVar(&c.Log.File, "log-file", c.Log.File, "path of the rotating log file used by the file log sink")
+	fs.IntVar(&c.Log.RingSize, "log-ring-size", c.Log.RingSize, "number of records kept in memory by the ring log sink")
+	fs.StringVar(&c.Admin.Addr, "admin-addr", c.Admin.Addr, "loopback address to serve the admin interface on, such as 127.0.0.1:8081 (disabled by default)")
+	fs.StringVar(&c.Signing.Key, "signkey", c.Signing.Key, "PEM encoded ed25519 private key used to sign share manifests")
+	fs.BoolVar(&c.Signing.OnionKey, "signkey-onion", c.Signing.OnionKey, "also use the signing key as the onion service key (persistent address)")
+	fs.StringVar(&c.Shares.FileDownloads, "file-downloads", c.Shares.FileDownloads, "how single file downloads count toward a share's download limit: count or free")
+	fs.StringVar(&c.Shares.FailedDownloads, "failed-downloads", c.Shares.FailedDownloads, "whether a failed transfer still counts as a download: keep or release")
+}
+
+// Parse loads the configuration for a command run with args: the file given
+// by -config, then environment variables, then the flags in args. The
+// returned configuration is not validated.
+func Parse(fs *flag.FlagSet, args []string) (*Config, error) {
+	path := ConfigFlag(fs)
+	c := Default()
+	c.Flags(fs)
+	// Parse once to find the configuration file...
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	loaded, err := Load(*path)
+	if err != nil {
+		return nil, err
+	}
+	// ...then again so flags given explicitly take precedence over it
+	*c = *loaded
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
@@ -1,16 +1,18 @@
 module github.com/ciehanski/onionbox
 
 require (
-	github.com/BurntSushi/toml v0.3.1 // indirect
+	github.com/BurntSushi/toml v0.3.1
 	github.com/Pallinder/go-randomdata v1.2.0
 	github.com/cretz/bine v0.1.0
 	github.com/ipsn/go-libtor v1.0.294
+	github.com/klauspost/compress v1.11.13
 	github.com/skip2/go-qrcode v0.0.0-20200519171959-a3b48390827e
+	golang.org/x/crypto v0.0.0-20190404164418-38d8ce5564a5
 	golang.org/x/net v0.0.0-20190125091013-d26f9f9a57f3 // indirect
 	golang.org/x/sys v0.0.0-20191210023423-ac6580df4449
 	golang.org/x/text v0.3.0 // indirect
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0
-	gopkg.in/yaml.v2 v2.2.2 // indirect
+	gopkg.in/yaml.v2 v2.2.2
 )
 
-go 1.14
+go 1.21
@@ -0,0 +1,62 @@
+package onionbox
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// AdminHandler serves the operator's admin interface for the onion services
+// published by one process. It must only ever be served on a loopback
+// address, never over the onion service.
+//
+// The logs are the first service's ring sink, which the others log to too.
+// The bootstrap progress and DoS defenses are reported for every service,
+// keyed by its Name.
+func AdminHandler(obs ...*Onionbox) http.Handler {
+	mux := http.NewServeMux()
+	if len(obs) > 0 {
+		mux.HandleFunc("/logs", obs[0].adminLogs)
+	}
+	mux.HandleFunc("/bootstrap", adminServices(obs, "Error writing bootstrap status to admin client", func(ob *Onionbox) interface{} {
+		return ob.Bootstrap()
+	}))
+	mux.HandleFunc("/dos", adminServices(obs, "Error writing DoS defenses to admin client", func(ob *Onionbox) interface{} {
+		return ob.DoSDefenses
+	}))
+	return mux
+}
+
+// adminLogs serves the records held by the in-memory log sink as JSON lines.
+func (ob *Onionbox) adminLogs(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Invalid HTTP Method.", http.StatusMethodNotAllowed)
+		return
+	}
+	if ob.logRing == nil {
+		http.Error(w, "In-memory logging is not enabled.", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if _, err := ob.logRing.WriteTo(w); err != nil {
+		ob.logger().Warn("Error writing logs to admin client", "err", err)
+	}
+}
+
+// adminServices serves status, as reported by each of obs, as a JSON object
+// keyed by service name.
+func adminServices(obs []*Onionbox, errMsg string, status func(ob *Onionbox) interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Invalid HTTP Method.", http.StatusMethodNotAllowed)
+			return
+		}
+		services := make(map[string]interface{}, len(obs))
+		for _, ob := range obs {
+			services[ob.Name] = status(ob)
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if err := json.NewEncoder(w).Encode(services); err != nil && len(obs) > 0 {
+			obs[0].logger().Warn(errMsg, "err", err)
+		}
+	}
+}
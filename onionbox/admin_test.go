@@ -0,0 +1,32 @@
+package onionbox
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAdminLogs(t *testing.T) {
+	ob := &Onionbox{}
+	handler := AdminHandler(ob)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(t, "GET", "/logs", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected response code %v without a ring sink, got %v", http.StatusNotFound, w.Code)
+	}
+
+	ob.LogSink = LogSinkRing
+	if err := ob.SetupLogging(); err != nil {
+		t.Fatal(err)
+	}
+	ob.Logger.Info("Final download completed", LogKeyShare, "sillyname")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(t, "GET", "/logs", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code %v, got %v", http.StatusOK, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Final download completed") {
+		t.Errorf("Expected logged record, got %q", w.Body.String())
+	}
+}
@@ -0,0 +1,213 @@
+package onionbox
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cretz/bine/control"
+	"github.com/cretz/bine/tor"
+	"github.com/cretz/bine/torutil"
+)
+
+// DefaultPublishTimeout is how long Tor is given to bootstrap and publish
+// the onion service.
+const DefaultPublishTimeout = 3 * time.Minute
+
+// ErrPublishTimeout is returned by Init when the onion service was not
+// published within the publish timeout.
+var ErrPublishTimeout = errors.New("onion service was not published in time")
+
+// BootstrapStatus is Tor's progress starting up and publishing the onion
+// service.
+type BootstrapStatus struct {
+	// Progress is the bootstrap percentage, from 0 to 100, and Tag and
+	// Summary describe its current phase.
+	Progress int    `json:"progress"`
+	Tag      string `json:"tag"`
+	Summary  string `json:"summary"`
+	// Published is set once a descriptor of the onion service has been
+	// uploaded, making it reachable.
+	Published bool `json:"published"`
+	// Problem is the latest diagnosed startup failure, with advice on how
+	// to fix it.
+	Problem   string    `json:"problem,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Bootstrap returns Tor's latest startup progress.
+func (ob *Onionbox) Bootstrap() BootstrapStatus {
+	ob.bootstrapMu.Lock()
+	defer ob.bootstrapMu.Unlock()
+	return ob.bootstrap
+}
+
+// torStartupEvents are the control port events watched while Tor starts.
+var torStartupEvents = []control.EventCode{
+	control.EventCodeStatusClient,
+	control.EventCodeStatusGeneral,
+	control.EventCodeHSDesc,
+}
+
+// watchBootstrap follows t's bootstrap progress and diagnoses failures
+// until the returned function is called. Events are only read from the
+// control connection while bine waits on them, which it does for the whole
+// of Listen.
+func (ob *Onionbox) watchBootstrap(t *tor.Tor) (func(), error) {
+	// Tor may have made progress before the listener was added
+	if info, err := t.Control.GetInfo("status/bootstrap-phase"); err == nil && len(info) == 1 {
+		ob.handleTorEvent(control.ParseStatusEvent(control.EventCodeStatusClient, info[0].Val))
+	}
+	events := make(chan control.Event, 16)
+	if err := t.Control.AddEventListener(events, torStartupEvents...); err != nil {
+		return nil, err
+	}
+	// bine sends events without ever dropping them, so keep receiving for
+	// good: one already on its way after the listener is removed would
+	// otherwise block the control connection.
+	go func() {
+		for event := range events {
+			ob.handleTorEvent(event)
+		}
+	}()
+	return func() {
+		if err := t.Control.RemoveEventListener(events, torStartupEvents...); err != nil {
+			ob.logger().Debug("Error removing Tor event listener", "err", err)
+		}
+	}, nil
+}
+
+// handleTorEvent updates the bootstrap status from a control port event.
+func (ob *Onionbox) handleTorEvent(event control.Event) {
+	ob.bootstrapMu.Lock()
+	status := ob.bootstrap
+	switch e := event.(type) {
+	case *control.StatusEvent:
+		// bine splits quoted values such as SUMMARY on their spaces
+		e.Arguments = statusArguments(e.Raw)
+		switch {
+		case e.Type == control.EventCodeStatusClient && e.Action == "BOOTSTRAP":
+			if progress, err := strconv.Atoi(e.Arguments["PROGRESS"]); err == nil {
+				status.Progress = progress
+			}
+			status.Tag, status.Summary = e.Arguments["TAG"], e.Arguments["SUMMARY"]
+			if e.Severity == "WARN" || e.Severity == "ERR" {
+				status.Problem = diagnoseBootstrapWarning(e.Arguments)
+			}
+		case e.Type == control.EventCodeStatusGeneral && e.Action == "CLOCK_SKEW":
+			status.Problem = diagnoseClockSkew(e.Arguments)
+		default:
+			ob.bootstrapMu.Unlock()
+			return
+		}
+	case *control.HSDescEvent:
+		switch e.Action {
+		case "UPLOADED":
+			status.Published = true
+		case "FAILED":
+			status.Problem = fmt.Sprintf("Uploading the onion service descriptor to %s failed (%s). "+
+				"Tor keeps retrying; if this persists, check that your clock is correct and your network is stable.",
+				e.HSDir, e.Reason)
+		default:
+			ob.bootstrapMu.Unlock()
+			return
+		}
+	default:
+		ob.bootstrapMu.Unlock()
+		return
+	}
+	problem := status.Problem != "" && status.Problem != ob.bootstrap.Problem
+	status.UpdatedAt = time.Now()
+	ob.bootstrap = status
+	ob.bootstrapMu.Unlock()
+
+	if problem {
+		ob.logger().Warn("Tor startup problem", "problem", status.Problem)
+	}
+	ob.logger().Debug("Tor bootstrap progress", "progress", status.Progress, "tag", status.Tag, "published", status.Published)
+	if ob.OnBootstrap != nil {
+		ob.OnBootstrap(status)
+	}
+}
+
+// diagnoseBootstrapWarning explains a bootstrap warning's REASON and how to
+// fix it.
+func diagnoseBootstrapWarning(args map[string]string) string {
+	switch args["REASON"] {
+	case "CONNECTREFUSED", "NOROUTE", "TIMEOUT", "IOERROR", "CONNECTRESET":
+		return fmt.Sprintf("Tor cannot reach the Tor network (%s: %s). Your network may be blocking Tor; "+
+			"try a custom torrc with bridges or a pluggable transport.", args["REASON"], args["WARNING"])
+	case "RESOURCELIMIT":
+		return fmt.Sprintf("Tor ran out of resources connecting to the network (%s). "+
+			"Raise the open file limit or free up memory.", args["WARNING"])
+	case "IDENTITY":
+		return fmt.Sprintf("A Tor relay presented an unexpected identity (%s). "+
+			"Something on your network may be intercepting connections.", args["WARNING"])
+	case "DONE":
+		return fmt.Sprintf("A Tor relay closed the connection (%s). This usually clears up on its own.", args["WARNING"])
+	default:
+		return fmt.Sprintf("Tor reported a problem bootstrapping: %s.", args["WARNING"])
+	}
+}
+
+// diagnoseClockSkew explains a CLOCK_SKEW status event.
+func diagnoseClockSkew(args map[string]string) string {
+	skew := args["SKEW"]
+	if s, err := strconv.Atoi(skew); err == nil {
+		skew = (time.Duration(s) * time.Second).String()
+	}
+	return fmt.Sprintf("Your system clock is off by %s according to %s. Tor needs an accurate clock: "+
+		"synchronize it, for example with NTP, and restart onionbox.", skew, args["SOURCE"])
+}
+
+// publishTimeoutError explains why the onion service could not be published
+// in time from the last bootstrap status.
+func publishTimeoutError(status BootstrapStatus) error {
+	var hint string
+	switch {
+	case status.Problem != "":
+		hint = status.Problem
+	case status.Progress < 100:
+		hint = "Tor is still bootstrapping; your connection may be slow or filtered, try a longer publish timeout or bridges."
+	default:
+		hint = "Tor is connected but could not publish the onion service; try a longer publish timeout."
+	}
+	return fmt.Errorf("%w: Tor reached %d%% (%s). %s", ErrPublishTimeout, status.Progress, status.Summary, hint)
+}
+
+// statusArguments parses the KEY=VALUE arguments of a raw status event,
+// whose values may be quoted strings containing spaces.
+func statusArguments(raw string) map[string]string {
+	args := map[string]string{}
+	// Skip the severity and action
+	_, raw, _ = torutil.PartitionString(raw, ' ')
+	_, raw, _ = torutil.PartitionString(raw, ' ')
+	for raw = strings.TrimSpace(raw); raw != ""; raw = strings.TrimSpace(raw) {
+		key, rest, ok := torutil.PartitionString(raw, '=')
+		if !ok {
+			break
+		}
+		var val string
+		if strings.HasPrefix(rest, "\"") {
+			// Find the closing quote, skipping escaped characters
+			end := 1
+			for end < len(rest) && rest[end] != '"' {
+				if rest[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(rest) {
+				end = len(rest) - 1
+			}
+			val, _ = torutil.UnescapeSimpleQuotedString(rest[:end+1])
+			raw = rest[end+1:]
+		} else {
+			val, raw, _ = torutil.PartitionString(rest, ' ')
+		}
+		args[key] = val
+	}
+	return args
+}
@@ -0,0 +1,90 @@
+package onionbox
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cretz/bine/control"
+)
+
+func TestHandleTorEvent(t *testing.T) {
+	var reported []BootstrapStatus
+	ob := Onionbox{OnBootstrap: func(s BootstrapStatus) { reported = append(reported, s) }}
+
+	ob.handleTorEvent(control.ParseStatusEvent(control.EventCodeStatusClient,
+		`NOTICE BOOTSTRAP PROGRESS=45 TAG=requesting_descriptors SUMMARY="Asking for relay descriptors"`))
+	if s := ob.Bootstrap(); s.Progress != 45 || s.Tag != "requesting_descriptors" || s.Summary != "Asking for relay descriptors" {
+		t.Errorf("unexpected status %+v", s)
+	}
+
+	ob.handleTorEvent(control.ParseStatusEvent(control.EventCodeStatusClient,
+		`WARN BOOTSTRAP PROGRESS=45 TAG=requesting_descriptors SUMMARY="Asking for relay descriptors" WARNING="Connection refused" REASON=CONNECTREFUSED COUNT=3 RECOMMENDATION=warn`))
+	if s := ob.Bootstrap(); !strings.Contains(s.Problem, "cannot reach the Tor network") {
+		t.Errorf("expected a blocked network diagnosis, got %q", s.Problem)
+	}
+
+	ob.handleTorEvent(control.ParseStatusEvent(control.EventCodeStatusGeneral,
+		`WARN CLOCK_SKEW SKEW=-7200 SOURCE=CONSENSUS`))
+	if s := ob.Bootstrap(); !strings.Contains(s.Problem, "clock is off by -2h0m0s") {
+		t.Errorf("expected a clock skew diagnosis, got %q", s.Problem)
+	}
+
+	// Unrelated events change nothing
+	ob.handleTorEvent(control.ParseStatusEvent(control.EventCodeStatusClient, `NOTICE CIRCUIT_ESTABLISHED`))
+	if len(reported) != 3 {
+		t.Errorf("expected 3 reported updates, got %d", len(reported))
+	}
+
+	ob.handleTorEvent(control.ParseHSDescEvent(`UPLOADED sillyname UNKNOWN $ABCDEF`))
+	if s := ob.Bootstrap(); !s.Published {
+		t.Error("expected the onion service to be published")
+	}
+}
+
+func TestPublishTimeoutError(t *testing.T) {
+	err := publishTimeoutError(BootstrapStatus{Progress: 10, Summary: "Finishing handshake"})
+	if !errors.Is(err, ErrPublishTimeout) {
+		t.Errorf("expected %v, got %v", ErrPublishTimeout, err)
+	}
+	if !strings.Contains(err.Error(), "10% (Finishing handshake)") {
+		t.Errorf("expected the last progress in %q", err)
+	}
+}
+
+func TestAdminBootstrap(t *testing.T) {
+	ob := &Onionbox{Name: "files"}
+	ob.handleTorEvent(control.ParseStatusEvent(control.EventCodeStatusClient,
+		`NOTICE BOOTSTRAP PROGRESS=100 TAG=done SUMMARY="Done"`))
+	other := &Onionbox{Name: "chat"}
+	other.handleTorEvent(control.ParseStatusEvent(control.EventCodeStatusClient,
+		`NOTICE BOOTSTRAP PROGRESS=10 TAG=conn_done SUMMARY="Connected to a relay"`))
+	w := httptest.NewRecorder()
+	AdminHandler(ob, other).ServeHTTP(w, newRequest(t, "GET", "/bootstrap", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code %v, got %v", http.StatusOK, w.Code)
+	}
+	var services map[string]BootstrapStatus
+	if err := json.NewDecoder(w.Body).Decode(&services); err != nil {
+		t.Fatal(err)
+	}
+	if status := services["files"]; status.Progress != 100 || status.Tag != "done" {
+		t.Errorf("unexpected status %+v", status)
+	}
+	if status := services["chat"]; status.Progress != 10 || status.Tag != "conn_done" {
+		t.Errorf("unexpected status of the other service %+v", status)
+	}
+}
+
+func TestStatusArguments(t *testing.T) {
+	args := statusArguments(`WARN BOOTSTRAP PROGRESS=5 SUMMARY="Said \"hi\" twice" REASON=DONE`)
+	if args["PROGRESS"] != "5" || args["SUMMARY"] != `Said "hi" twice` || args["REASON"] != "DONE" {
+		t.Errorf("unexpected arguments %q", args)
+	}
+	if args := statusArguments(`WARN BOOTSTRAP SUMMARY="unterminated`); args["SUMMARY"] != "" {
+		t.Errorf("unexpected arguments %q", args)
+	}
+}
@@ -0,0 +1,147 @@
+package onionbox
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Bridge is a parsed bridge line, as given to Tor's Bridge option:
+//
+//	[transport] host:port [fingerprint] [key=value ...]
+type Bridge struct {
+	// Transport is the pluggable transport, such as obfs4, snowflake or
+	// meek_lite. It is empty for plain bridges.
+	Transport   string
+	Addr        string
+	Fingerprint string
+	// Args are the transport's key=value arguments, such as obfs4's cert.
+	Args []string
+}
+
+var (
+	// ErrInvalidBridge is returned for a bridge line Tor would not accept.
+	ErrInvalidBridge = errors.New("invalid bridge line")
+	// ErrNoTransportPlugin is returned for a bridge whose transport has no
+	// plugin binary configured.
+	ErrNoTransportPlugin = errors.New("no transport plugin configured")
+)
+
+var transportNameReg = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// ParseBridgeLine parses and validates a bridge line.
+func ParseBridgeLine(line string) (Bridge, error) {
+	var b Bridge
+	fields := strings.Fields(line)
+	if len(fields) > 0 && strings.EqualFold(fields[0], "Bridge") { // Lines copied from a torrc
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return b, fmt.Errorf("%w: empty", ErrInvalidBridge)
+	}
+	if _, _, err := net.SplitHostPort(fields[0]); err != nil {
+		if !transportNameReg.MatchString(fields[0]) {
+			return b, fmt.Errorf("%w: %q is neither a transport nor an address", ErrInvalidBridge, fields[0])
+		}
+		b.Transport, fields = fields[0], fields[1:]
+	}
+	if len(fields) == 0 {
+		return b, fmt.Errorf("%w: missing address", ErrInvalidBridge)
+	}
+	if _, port, err := net.SplitHostPort(fields[0]); err != nil || port == "" {
+		return b, fmt.Errorf("%w: %q is not a host:port address", ErrInvalidBridge, fields[0])
+	}
+	b.Addr, fields = fields[0], fields[1:]
+	if len(fields) > 0 && !strings.Contains(fields[0], "=") {
+		if fp, err := hex.DecodeString(fields[0]); err != nil || len(fp) != 20 {
+			return b, fmt.Errorf("%w: %q is not a 40 character hex fingerprint", ErrInvalidBridge, fields[0])
+		}
+		b.Fingerprint, fields = strings.ToUpper(fields[0]), fields[1:]
+	}
+	for _, arg := range fields {
+		if i := strings.Index(arg, "="); i < 1 {
+			return b, fmt.Errorf("%w: %q is not a key=value argument", ErrInvalidBridge, arg)
+		}
+		if b.Transport == "" {
+			return b, fmt.Errorf("%w: arguments need a transport", ErrInvalidBridge)
+		}
+	}
+	b.Args = fields
+	return b, nil
+}
+
+// String formats the bridge as a line for Tor's Bridge option.
+func (b Bridge) String() string {
+	fields := make([]string, 0, 3+len(b.Args))
+	if b.Transport != "" {
+		fields = append(fields, b.Transport)
+	}
+	fields = append(fields, b.Addr)
+	if b.Fingerprint != "" {
+		fields = append(fields, b.Fingerprint)
+	}
+	return strings.Join(append(fields, b.Args...), " ")
+}
+
+// ValidateTransportPlugin checks that path is an executable file Tor can
+// launch. Tor splits its plugin option on spaces, so path cannot have any.
+func ValidateTransportPlugin(path string) error {
+	if strings.ContainsAny(path, " \t") {
+		return fmt.Errorf("%s: path cannot contain spaces", path)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("%s is not an executable file", path)
+	}
+	return nil
+}
+
+// bridgeArgs returns the Tor command line arguments enabling ob's bridges
+// and the transport plugins they use.
+func (ob *Onionbox) bridgeArgs() ([]string, error) {
+	if len(ob.Bridges) == 0 {
+		return nil, nil
+	}
+	args := []string{"--UseBridges", "1"}
+	// Tor launches one plugin process for all the transports it serves
+	transportsByPlugin := map[string][]string{}
+	for _, line := range ob.Bridges {
+		b, err := ParseBridgeLine(line)
+		if err != nil {
+			return nil, err
+		}
+		if b.Transport != "" {
+			plugin, ok := ob.TransportPlugins[b.Transport]
+			if !ok {
+				return nil, fmt.Errorf("%w for transport %s", ErrNoTransportPlugin, b.Transport)
+			}
+			if !slices.Contains(transportsByPlugin[plugin], b.Transport) {
+				transportsByPlugin[plugin] = append(transportsByPlugin[plugin], b.Transport)
+			}
+		}
+		args = append(args, "--Bridge", b.String())
+	}
+	plugins := make([]string, 0, len(transportsByPlugin))
+	for plugin := range transportsByPlugin {
+		if err := ValidateTransportPlugin(plugin); err != nil {
+			return nil, err
+		}
+		plugins = append(plugins, plugin)
+	}
+	sort.Strings(plugins)
+	for _, plugin := range plugins {
+		transports := transportsByPlugin[plugin]
+		sort.Strings(transports)
+		args = append(args, "--ClientTransportPlugin", strings.Join(transports, ",")+" exec "+plugin)
+	}
+	return args, nil
+}
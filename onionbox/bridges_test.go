@@ -0,0 +1,89 @@
+package onionbox
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const (
+	testObfs4Bridge     = "obfs4 192.0.2.1:443 0123456789ABCDEF0123456789ABCDEF01234567 cert=c2VjcmV0 iat-mode=0"
+	testSnowflakeBridge = "snowflake 192.0.2.3:80 2B280B23E1107BB62ABFC40DDCC8824814F80A72 url=https://snowflake-broker.example/ ice=stun:stun.example:3478,stun:stun2.example:3478"
+)
+
+func TestParseBridgeLine(t *testing.T) {
+	b, err := ParseBridgeLine("Bridge " + testObfs4Bridge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Transport != "obfs4" || b.Addr != "192.0.2.1:443" || len(b.Args) != 2 {
+		t.Errorf("unexpected bridge %+v", b)
+	}
+	if b.String() != testObfs4Bridge {
+		t.Errorf("expected %q, got %q", testObfs4Bridge, b.String())
+	}
+	if b, err := ParseBridgeLine("[2001:db8::1]:9001"); err != nil || b.Transport != "" {
+		t.Errorf("expected a plain bridge, got %+v, %v", b, err)
+	}
+
+	for _, line := range []string{
+		"",
+		"obfs4",
+		"obfs4 192.0.2.1",
+		"obfs-4 192.0.2.1:443",
+		"obfs4 192.0.2.1:443 NOTAFINGERPRINT",
+		"obfs4 192.0.2.1:443 0123456789ABCDEF0123456789ABCDEF01234567 cert",
+		"192.0.2.1:443 cert=c2VjcmV0",
+	} {
+		if _, err := ParseBridgeLine(line); !errors.Is(err, ErrInvalidBridge) {
+			t.Errorf("expected %q to be invalid, got %v", line, err)
+		}
+	}
+}
+
+func TestTorStartConfBridges(t *testing.T) {
+	dir := t.TempDir()
+	plugin := filepath.Join(dir, "lyrebird")
+	if err := ioutil.WriteFile(plugin, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	ob := &Onionbox{
+		Bridges:          []string{testSnowflakeBridge, testObfs4Bridge, "192.0.2.2:9001"},
+		TransportPlugins: map[string]string{"obfs4": plugin, "snowflake": plugin},
+	}
+	conf, err := ob.torStartConf(ioutil.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"--UseBridges", "1",
+		"--Bridge", testSnowflakeBridge,
+		"--Bridge", testObfs4Bridge,
+		"--Bridge", "192.0.2.2:9001",
+		"--ClientTransportPlugin", "obfs4,snowflake exec " + plugin,
+	}
+	if !reflect.DeepEqual(conf.ExtraArgs, want) {
+		t.Errorf("expected args %q, got %q", want, conf.ExtraArgs)
+	}
+
+	// Without bridges Tor starts as before
+	if conf, err := (&Onionbox{}).torStartConf(ioutil.Discard); err != nil || conf.ExtraArgs != nil {
+		t.Errorf("expected no extra args, got %q, %v", conf.ExtraArgs, err)
+	}
+
+	ob.TransportPlugins = map[string]string{"obfs4": plugin}
+	if _, err := ob.torStartConf(ioutil.Discard); !errors.Is(err, ErrNoTransportPlugin) {
+		t.Errorf("expected %v, got %v", ErrNoTransportPlugin, err)
+	}
+
+	ob.Bridges = []string{testObfs4Bridge}
+	if err := os.Chmod(plugin, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ob.torStartConf(ioutil.Discard); err == nil {
+		t.Error("expected a non executable plugin to be refused")
+	}
+}
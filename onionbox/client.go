@@ -0,0 +1,27 @@
+package onionbox
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+)
+
+// TorClient starts Tor as a client only and returns an HTTP client whose
+// requests are made over it, along with a function stopping Tor once the
+// client is no longer needed.
+func (ob *Onionbox) TorClient(ctx context.Context) (*http.Client, func() error, error) {
+	t, err := ob.startTor(ctx, newLogWriter(ob.logger(), slog.LevelDebug, "tor"))
+	if err != nil {
+		return nil, nil, err
+	}
+	// Creating the dialer waits for Tor to bootstrap
+	dialer, err := t.Dialer(ctx, nil)
+	if err != nil {
+		t.Close()
+		return nil, nil, err
+	}
+	client := &http.Client{
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+	}
+	return client, t.Close, nil
+}
@@ -0,0 +1,92 @@
+package onionbox
+
+import (
+	"strconv"
+
+	"github.com/cretz/bine/control"
+)
+
+// DoSDefenses configures Tor's mitigations against floods of an onion
+// service. The zero value leaves Tor's defaults.
+type DoSDefenses struct {
+	// MaxStreams caps the streams a single rendezvous circuit may open,
+	// zero meaning no limit. With MaxStreamsCloseCircuit a circuit going
+	// over it is torn down instead of only having the stream refused.
+	MaxStreams             int  `json:"max_streams"`
+	MaxStreamsCloseCircuit bool `json:"max_streams_close_circuit"`
+	// PoW makes clients solve a proof of work puzzle, harder the more
+	// loaded the service is, before being let in. It needs Tor 0.4.8 or
+	// later. PoWQueueRate and PoWQueueBurst bound the introduction
+	// requests handled per second from the queue, Tor's defaults if zero.
+	PoW           bool `json:"pow"`
+	PoWQueueRate  int  `json:"pow_queue_rate,omitempty"`
+	PoWQueueBurst int  `json:"pow_queue_burst,omitempty"`
+	// IntroDoS has the service's introduction points rate limit the
+	// introductions they relay to IntroDoSRatePerSec, with bursts of up to
+	// IntroDoSBurstPerSec, Tor's defaults if zero. Without it the limits
+	// follow the Tor network's consensus. It needs Tor 0.4.2 or later, and
+	// as ADD_ONION cannot carry it the service is configured as a torrc
+	// HiddenServiceDir would be, see configureOnion.
+	IntroDoS            bool `json:"intro_dos_defense"`
+	IntroDoSRatePerSec  int  `json:"intro_dos_rate_per_sec,omitempty"`
+	IntroDoSBurstPerSec int  `json:"intro_dos_burst_per_sec,omitempty"`
+}
+
+// powArgs returns the ADD_ONION arguments enabling proof of work.
+func (d DoSDefenses) powArgs() string {
+	if !d.PoW {
+		return ""
+	}
+	args := " PoWDefensesEnabled=1"
+	if d.PoWQueueRate > 0 {
+		args += " PoWQueueRate=" + strconv.Itoa(d.PoWQueueRate)
+	}
+	if d.PoWQueueBurst > 0 {
+		args += " PoWQueueBurst=" + strconv.Itoa(d.PoWQueueBurst)
+	}
+	return args
+}
+
+// torrcOptions returns the defenses as options of a HiddenServiceDir
+// service.
+func (d DoSDefenses) torrcOptions() []*control.KeyVal {
+	var opts []*control.KeyVal
+	option := func(key string, value int) {
+		opts = append(opts, control.NewKeyVal(key, strconv.Itoa(value)))
+	}
+	if d.MaxStreams > 0 {
+		option("HiddenServiceMaxStreams", d.MaxStreams)
+		if d.MaxStreamsCloseCircuit {
+			option("HiddenServiceMaxStreamsCloseCircuit", 1)
+		}
+	}
+	if d.IntroDoS {
+		option("HiddenServiceEnableIntroDoSDefense", 1)
+		if d.IntroDoSRatePerSec > 0 {
+			option("HiddenServiceEnableIntroDoSRatePerSec", d.IntroDoSRatePerSec)
+		}
+		if d.IntroDoSBurstPerSec > 0 {
+			option("HiddenServiceEnableIntroDoSBurstPerSec", d.IntroDoSBurstPerSec)
+		}
+	}
+	if d.PoW {
+		option("HiddenServicePoWDefensesEnabled", 1)
+		if d.PoWQueueRate > 0 {
+			option("HiddenServicePoWQueueRate", d.PoWQueueRate)
+		}
+		if d.PoWQueueBurst > 0 {
+			option("HiddenServicePoWQueueBurst", d.PoWQueueBurst)
+		}
+	}
+	return opts
+}
+
+// logAttrs returns the defenses as log attributes.
+func (d DoSDefenses) logAttrs() []any {
+	return []any{
+		"max_streams", d.MaxStreams,
+		"max_streams_close_circuit", d.MaxStreamsCloseCircuit,
+		"pow", d.PoW,
+		"intro_dos_defense", d.IntroDoS,
+	}
+}
@@ -0,0 +1,69 @@
+package onionbox
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPoWArgs(t *testing.T) {
+	tests := []struct {
+		defenses DoSDefenses
+		args     string
+	}{
+		{DoSDefenses{}, ""},
+		{DoSDefenses{MaxStreams: 5, PoWQueueRate: 10}, ""},
+		{DoSDefenses{PoW: true}, " PoWDefensesEnabled=1"},
+		{DoSDefenses{PoW: true, PoWQueueRate: 10, PoWQueueBurst: 20}, " PoWDefensesEnabled=1 PoWQueueRate=10 PoWQueueBurst=20"},
+	}
+	for _, tt := range tests {
+		if got := tt.defenses.powArgs(); got != tt.args {
+			t.Errorf("%+v: expected %q, got %q", tt.defenses, tt.args, got)
+		}
+	}
+}
+
+func TestTorrcOptions(t *testing.T) {
+	tests := []struct {
+		defenses DoSDefenses
+		options  string
+	}{
+		{DoSDefenses{}, ""},
+		{DoSDefenses{IntroDoSRatePerSec: 25}, ""},
+		{DoSDefenses{IntroDoS: true}, "HiddenServiceEnableIntroDoSDefense=1"},
+		{DoSDefenses{IntroDoS: true, IntroDoSRatePerSec: 25, IntroDoSBurstPerSec: 200}, "HiddenServiceEnableIntroDoSDefense=1 HiddenServiceEnableIntroDoSRatePerSec=25 HiddenServiceEnableIntroDoSBurstPerSec=200"},
+		{DoSDefenses{MaxStreams: 5, MaxStreamsCloseCircuit: true, PoW: true, PoWQueueRate: 10}, "HiddenServiceMaxStreams=5 HiddenServiceMaxStreamsCloseCircuit=1 HiddenServicePoWDefensesEnabled=1 HiddenServicePoWQueueRate=10"},
+	}
+	for _, tt := range tests {
+		var options []string
+		for _, opt := range tt.defenses.torrcOptions() {
+			options = append(options, opt.Key+"="+opt.Val)
+		}
+		if got := strings.Join(options, " "); got != tt.options {
+			t.Errorf("%+v: expected %q, got %q", tt.defenses, tt.options, got)
+		}
+	}
+}
+
+func TestAdminDoS(t *testing.T) {
+	ob := &Onionbox{Name: "files", DoSDefenses: DoSDefenses{MaxStreams: 10, PoW: true, IntroDoS: true, IntroDoSRatePerSec: 25}}
+	other := &Onionbox{Name: "chat", DoSDefenses: DoSDefenses{MaxStreams: 5}}
+	w := httptest.NewRecorder()
+	AdminHandler(ob, other).ServeHTTP(w, newRequest(t, "GET", "/dos", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code %v, got %v", http.StatusOK, w.Code)
+	}
+	var services map[string]map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &services); err != nil {
+		t.Fatal(err)
+	}
+	status := services["files"]
+	if status["max_streams"] != 10.0 || status["pow"] != true || status["intro_dos_defense"] != true || status["intro_dos_rate_per_sec"] != 25.0 {
+		t.Errorf("unexpected DoS defenses %v", status)
+	}
+	if status := services["chat"]; status["max_streams"] != 5.0 || status["pow"] != false {
+		t.Errorf("unexpected DoS defenses of the other service %v", status)
+	}
+}
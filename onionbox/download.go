@@ -1,42 +1,38 @@
 package onionbox
 
 import (
-	"crypto/subtle"
-	"fmt"
+	"errors"
 	"html/template"
+	"mime"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"syscall"
 
 	"github.com/ciehanski/onionbox/onionbuffer"
 	"github.com/ciehanski/onionbox/templates"
 )
 
-func (ob *Onionbox) download(w http.ResponseWriter, r *http.Request) {
+// download serves the share oBuffer the router found.
+func (ob *Onionbox) download(w http.ResponseWriter, r *http.Request, oBuffer *onionbuffer.OnionBuffer) {
 	switch r.Method {
 	case http.MethodGet:
-		ob.downloadGet(w, r)
+		ob.downloadGet(w, r, oBuffer)
 	case http.MethodPost: // If buffer was password protected
-		ob.downloadPost(w, r)
+		ob.downloadPost(w, r, oBuffer)
 	default:
-		http.Error(w, "Invalid HTTP Method.", http.StatusMethodNotAllowed)
+		ob.httpError(w, r, ErrMethodNotAllowed)
 		return
 	}
 }
 
-func (ob *Onionbox) downloadGet(w http.ResponseWriter, r *http.Request) {
-	oBuffer := ob.Store.Get(r.URL.Path[1:])
-	if oBuffer == nil {
-		ob.Logf("File %s not found in store", oBuffer.Name)
-		http.Error(w, "Error finding requested file.", http.StatusInternalServerError)
-		return
-	}
-
+func (ob *Onionbox) downloadGet(w http.ResponseWriter, r *http.Request, oBuffer *onionbuffer.OnionBuffer) {
 	if oBuffer.Encrypted {
 		csrf, err := createCSRF()
 		if err != nil {
-			ob.Logf("Error creating CSRF token: %v", err)
-			http.Error(w, "Error displaying web page, please try refreshing.", http.StatusInternalServerError)
+			ob.logger().Error("Error creating CSRF token", "err", err)
+			ob.httpError(w, r, err)
 			return
 		}
 
@@ -52,129 +48,273 @@ func (ob *Onionbox) downloadGet(w http.ResponseWriter, r *http.Request) {
 
 		t, err := template.New("download_encrypted").Parse(templates.DownloadHTML) // Parse template
 		if err != nil {
-			ob.Logf("Error loading template: %v", err)
-			http.Error(w, "Error displaying web page, please try refreshing.", http.StatusInternalServerError)
+			ob.logger().Error("Error loading template", "err", err)
+			ob.httpError(w, r, err)
 			return
 		}
 
-		if err := t.Execute(w, csrf); err != nil { // Execute template
-			ob.Logf("Error executing template: %v", err)
-			http.Error(w, "Error displaying web page, please try refreshing.", http.StatusInternalServerError)
+		data := map[string]interface{}{
+			"CSRF":     csrf,
+			"Name":     oBuffer.Name,
+			"Checksum": oBuffer.Checksum,
+			"Signed":   ob.SigningKey != nil,
+			"Lifetime": oBuffer.Lifetime(),
+		}
+		if err := t.Execute(w, data); err != nil { // Execute template
+			ob.logger().Error("Error executing template", "err", err)
+			ob.httpError(w, r, err)
 			return
 		}
+	} else if wantsHTML(r) && r.URL.Query().Get("download") == "" {
+		// Browsers land on a listing of the share's files
+		ob.writeSharePage(w, r, oBuffer)
 	} else {
-		if oBuffer.DownloadLimit != 0 {
-			// If buffer's download limit has been reached
-			if oBuffer.Downloads >= oBuffer.DownloadLimit {
-				ob.Logf("Download limit reached for %s", oBuffer.Name)
-				if err := ob.Store.Destroy(oBuffer); err != nil {
-					ob.Logf("Error destroying onionbuffer from store: %v", err)
-				}
-				http.Error(w, "Download limit reached.", http.StatusUnauthorized)
-				return
-			}
-			// Increment files download count
-			oBuffer.Downloads++
-		}
 		chksmValid, err := oBuffer.ValidateChecksum() // Validate checksum
 		if err != nil {
-			ob.Logf("Error validating checksum: %v", err)
-			http.Error(w, "Error validating checksum.", http.StatusInternalServerError)
+			ob.logger().Error("Error validating checksum", "err", err)
+			ob.httpError(w, r, err)
 			return
 		}
 		if !chksmValid {
-			ob.Logf("Invalid checksum for file %s", oBuffer.Name)
-			http.Error(w, "Invalid checksum.", http.StatusInternalServerError)
+			ob.logger().Error("Invalid checksum", LogKeyShare, oBuffer.Name)
+			ob.httpError(w, r, onionbuffer.ErrInvalidChecksum)
+			return
+		}
+		// Claim a download before streaming starts
+		if !ob.claimDownload(w, r, oBuffer) {
 			return
 		}
 		// Set headers for browser to initiate download
-		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.zip", oBuffer.Name))
-		w.Header().Set("Content-Type", "application/zip; charset=utf-8")
-		w.Header().Set("Content-Length", strconv.Itoa(len(oBuffer.Bytes)))
-		// Write the zip bytes to the response for download
-		_, err = w.Write(oBuffer.Bytes)
+		ob.setDigestHeaders(w, oBuffer)
+		setFileHeaders(w, oBuffer)
+		w.Header().Set("Content-Length", strconv.FormatInt(oBuffer.Len(), 10))
+		// Stream the share bytes to the response for download
+		_, err = oBuffer.WriteTo(ob.pacedWriter(w, oBuffer.Len()))
+		ob.finishDownload(oBuffer, err != nil)
 		if err != nil {
-			ob.Logf("Error writing to client: %v", err)
-			http.Error(w, "Error writing to client.", http.StatusInternalServerError)
-			return
+			// Too late to tell the client, the download has begun
+			ob.logger().Warn("Error writing to client", "err", err)
 		}
 	}
 }
 
-func (ob *Onionbox) downloadPost(w http.ResponseWriter, r *http.Request) {
+func (ob *Onionbox) downloadPost(w http.ResponseWriter, r *http.Request, oBuffer *onionbuffer.OnionBuffer) {
 	if err := r.ParseForm(); err != nil {
-		ob.Logf("Error parsing upload form: %v", err)
-		http.Error(w, "Error parsing password form.", http.StatusInternalServerError)
+		ob.logger().Warn("Error parsing password form", "err", err)
+		ob.httpError(w, r, badRequest("Error parsing password form.", err))
 		return
 	}
 
 	// Check CSRF
-	csrfForm := r.PostFormValue(formCSRF)
-	csrfCookie, err := r.Cookie(cookieCSRF)
+	if err := checkCSRF(r); err != nil {
+		ob.logger().Warn("Error checking CSRF", "err", err)
+		ob.httpError(w, r, err)
+		return
+	}
+
+	// Refuse unavailable shares before trying the password, so an embargoed
+	// share cannot be used to guess it
+	if err := oBuffer.Downloadable(); err != nil {
+		ob.refuseDownload(w, r, oBuffer, err)
+		return
+	}
+
+	// Get password and decrypt share for download
+	pass := r.FormValue("password")
+	encryptedBytes, err := oBuffer.ReadAll()
 	if err != nil {
-		ob.Logf("Error getting CSRF cookie: %v", err)
-		http.Error(w, "Error getting CSRF.", http.StatusInternalServerError)
+		ob.logger().Error("Error reading buffer", "err", err)
+		ob.httpError(w, r, err)
 		return
 	}
-	if subtle.ConstantTimeCompare([]byte(csrfForm), []byte(csrfCookie.Value)) == 0 {
-		ob.Logf("Form CSRF and Cookie CSRF values do not match")
-		http.Error(w, "Invalid CSRF value.", http.StatusUnauthorized)
+	defer onionbuffer.Wipe(encryptedBytes)
+	decryptedBytes, err := onionbuffer.Decrypt(encryptedBytes, pass)
+	if err != nil {
+		ob.logger().Warn("Error decrypting buffer", "err", err)
+		ob.httpError(w, r, err)
 		return
 	}
+	// Lock memory allotted to decryptedBytes from being used in SWAP, and
+	// wipe it before unlocking it once the response is written
+	locked := true
+	if err := syscall.Mlock(decryptedBytes); err != nil {
+		ob.logger().Warn("Error mlocking allotted memory for decryptedBytes", "err", err)
+		locked = false
+	}
+	defer func() {
+		onionbuffer.Wipe(decryptedBytes)
+		if locked {
+			_ = syscall.Munlock(decryptedBytes)
+		}
+	}()
+	// Validate checksum of the decrypted share
+	if !oBuffer.MatchesChecksum(decryptedBytes) {
+		ob.logger().Error("Invalid checksum", LogKeyShare, oBuffer.Name)
+		ob.httpError(w, r, onionbuffer.ErrInvalidChecksum)
+		return
+	}
+	// Claim a download only once the password was correct
+	if !ob.claimDownload(w, r, oBuffer) {
+		return
+	}
+	// Set headers for browser to initiate download
+	ob.setDigestHeaders(w, oBuffer)
+	setFileHeaders(w, oBuffer)
+	w.Header().Set("Content-Length", strconv.Itoa(len(decryptedBytes)))
+	// Write the share bytes to the response for download
+	_, err = ob.pacedWriter(w, int64(len(decryptedBytes))).Write(decryptedBytes)
+	ob.finishDownload(oBuffer, err != nil)
+	if err != nil {
+		// Too late to tell the client, the download has begun
+		ob.logger().Warn("Error writing to client", "err", err)
+	}
+}
 
-	oBuffer := ob.Store.Get(r.URL.Path[1:])
-	if oBuffer == nil {
-		ob.Logf("File %s not found in store", oBuffer.Name)
-		http.Error(w, "Error finding requested file.", http.StatusInternalServerError)
+// downloadFile streams a single file out of a share's archive. Password
+// protected shares do not list their files, so they have no per file
+// downloads either.
+func (ob *Onionbox) downloadFile(w http.ResponseWriter, r *http.Request, oBuffer *onionbuffer.OnionBuffer, index string) {
+	if r.Method != http.MethodGet {
+		ob.httpError(w, r, ErrMethodNotAllowed)
+		return
+	}
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		ob.httpError(w, r, ErrPageNotFound)
+		return
+	}
+	// Snapshot the file, the share may be destroyed while it streams
+	info, err := oBuffer.File(i)
+	if errors.Is(err, onionbuffer.ErrFileNotFound) {
+		ob.httpError(w, r, ErrPageNotFound)
+		return
+	} else if err != nil {
+		ob.refuseDownload(w, r, oBuffer, err)
 		return
 	}
 
-	if oBuffer.DownloadLimit != 0 {
-		// If buffer's download limit has been reached
-		if oBuffer.Downloads >= oBuffer.DownloadLimit {
-			if err := ob.Store.Destroy(oBuffer); err != nil {
-				ob.Logf("Error destroying onionbuffer from store: %v", err)
-			}
-			ob.Logf("Download limit reached for %s", oBuffer.Name)
-			http.Error(w, "Download limit reached.", http.StatusUnauthorized)
+	counted := ob.FileDownloadPolicy != FileDownloadsFree
+	if counted && !ob.claimDownload(w, r, oBuffer) {
+		return
+	}
+	// Uncounted downloads still honour the share's embargo and expiry
+	if !counted {
+		if err := oBuffer.Available(); err != nil {
+			ob.refuseDownload(w, r, oBuffer, err)
 			return
 		}
-		// Increment files download count
-		oBuffer.Downloads++
 	}
-	// Validate checksum
-	chksmValid, err := oBuffer.ValidateChecksum()
+
+	// Set headers for browser to initiate download
+	if repr, legacy, err := onionbuffer.DigestHeaders(info.Checksum); err == nil {
+		w.Header().Set("Repr-Digest", repr)
+		w.Header().Set("Digest", legacy)
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(info.Name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": info.Name}))
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	// Stream the single file to the response for download
+	_, err = oBuffer.WriteFileTo(ob.pacedWriter(w, info.Size), i)
+	if counted {
+		ob.finishDownload(oBuffer, err != nil)
+	}
 	if err != nil {
-		ob.Logf("Error validating checksum: %v", err)
-		http.Error(w, "Error validating checksum.", http.StatusInternalServerError)
-		return
+		// Too late to tell the client, the download has begun
+		ob.logger().Warn("Error writing file to client", "err", err)
 	}
-	if !chksmValid {
-		ob.Logf("Invalid checksum for file %s", oBuffer.Name)
-		http.Error(w, "Invalid checksum.", http.StatusInternalServerError)
+}
+
+// writeSharePage lists the files of an unencrypted share, each with its own
+// download link next to a link to the whole archive.
+func (ob *Onionbox) writeSharePage(w http.ResponseWriter, r *http.Request, oBuffer *onionbuffer.OnionBuffer) {
+	t, err := template.New("share").Parse(templates.ShareHTML) // Parse template
+	if err != nil {
+		ob.logger().Error("Error loading template", "err", err)
+		ob.httpError(w, r, err)
 		return
 	}
-	// Get password and decrypt zip for download
-	pass := r.FormValue("password")
-	decryptedBytes, err := onionbuffer.Decrypt(oBuffer.Bytes, pass)
-	if err != nil {
-		ob.Logf("Error decrypting buffer: %v", err)
-		http.Error(w, "Error decrypting buffer.", http.StatusInternalServerError)
+	data := map[string]interface{}{
+		"Name":     oBuffer.Name,
+		"Files":    oBuffer.Files,
+		"FileName": oBuffer.FileName(),
+		"Checksum": oBuffer.Checksum,
+		"Signed":   ob.SigningKey != nil,
+		"Lifetime": oBuffer.Lifetime(),
+	}
+	if err := t.Execute(w, data); err != nil { // Execute template
+		ob.logger().Error("Error executing template", "err", err)
+		ob.httpError(w, r, err)
 		return
 	}
-	// Lock memory allotted to decryptedBytes from being used in SWAP
-	if err := syscall.Mlock(decryptedBytes); err != nil {
-		ob.Logf("Error mlocking allotted memory for decryptedBytes: %v", err)
+}
+
+// claimDownload atomically reserves one of oBuffer's downloads. If the
+// share cannot be downloaded right now the client is told why and false is
+// returned.
+func (ob *Onionbox) claimDownload(w http.ResponseWriter, r *http.Request, oBuffer *onionbuffer.OnionBuffer) bool {
+	if err := oBuffer.ClaimDownload(); err != nil {
+		ob.refuseDownload(w, r, oBuffer, err)
+		return false
+	}
+	return true
+}
+
+// refuseDownload tells the client why oBuffer cannot be downloaded.
+func (ob *Onionbox) refuseDownload(w http.ResponseWriter, r *http.Request, oBuffer *onionbuffer.OnionBuffer, err error) {
+	switch err {
+	case onionbuffer.ErrNotYetAvailable:
+		ob.logger().Info("Download of embargoed share refused", LogKeyShare, oBuffer.Name)
+	case onionbuffer.ErrExpired:
+		ob.logger().Info("Download of expired share refused", LogKeyShare, oBuffer.Name)
+	case onionbuffer.ErrDestroyed:
+		ob.logger().Info("Download of destroyed share refused", LogKeyShare, oBuffer.Name)
+	default:
+		ob.logger().Info("Download limit reached", LogKeyShare, oBuffer.Name)
+	}
+	ob.httpError(w, r, err)
+}
+
+// finishDownload ends a claimed download, releasing its slot on failure if
+// the operator chose to. The store destroys oBuffer as soon as its final
+// allowed download has completed.
+func (ob *Onionbox) finishDownload(oBuffer *onionbuffer.OnionBuffer, failed bool) {
+	name := oBuffer.Name
+	release := ob.FailedDownloadPolicy == FailedDownloadsRelease
+	destroyed, err := ob.Store.FinishDownload(oBuffer, failed, release)
+	if destroyed {
+		ob.logger().Info("Final download completed", LogKeyShare, name)
 	}
-	// Set headers for browser to initiate download
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.zip", oBuffer.Name))
-	w.Header().Set("Content-Type", "application/zip; charset=utf-8")
-	w.Header().Set("Content-Length", strconv.Itoa(len(oBuffer.Bytes)))
-	// Write the zip bytes to the response for download
-	_, err = w.Write(decryptedBytes)
 	if err != nil {
-		ob.Logf("Error writing to client: %v", err)
-		http.Error(w, "Error writing to client.", http.StatusInternalServerError)
+		ob.logger().Error("Error destroying onionbuffer from store", "err", err)
+	}
+}
+
+// wantsHTML reports whether the client is a browser asking for a web page.
+func wantsHTML(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// setDigestHeaders publishes the buffer's SHA-256 checksum in the Repr-Digest
+// and legacy Digest headers so recipients can verify their download.
+func (ob *Onionbox) setDigestHeaders(w http.ResponseWriter, oBuffer *onionbuffer.OnionBuffer) {
+	repr, legacy, err := oBuffer.DigestHeader()
+	if err != nil {
+		ob.logger().Error("Error encoding checksum header", "err", err)
 		return
 	}
+	w.Header().Set("Repr-Digest", repr)
+	w.Header().Set("Digest", legacy)
+}
+
+// setFileHeaders sets the headers for the browser to download the buffer
+// under its original file name, or its archive name.
+func setFileHeaders(w http.ResponseWriter, oBuffer *onionbuffer.OnionBuffer) {
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": oBuffer.FileName()}))
+	w.Header().Set("Content-Type", oBuffer.ContentType())
+	w.Header().Set("X-Content-Type-Options", "nosniff")
 }
@@ -0,0 +1,117 @@
+package onionbox
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+	"github.com/ciehanski/onionbox/onionstore"
+)
+
+func TestDownloadLimit(t *testing.T) {
+	ob := Onionbox{Store: onionstore.NewStore()}
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	oBuf := &onionbuffer.OnionBuffer{Name: "testinglimit", Bytes: append([]byte(nil), testFile...), DownloadLimit: 1}
+	oBuf.Checksum, _ = oBuf.GetChecksum()
+	if err := ob.Store.Add(oBuf); err != nil && err.Error() != "invalid argument" {
+		t.Fatal(err)
+	}
+
+	handler := http.HandlerFunc(ob.Router)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(t, "GET", "/testinglimit", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code %v, got %v", http.StatusOK, w.Code)
+	}
+	if w.Body.Len() != len(testFile) {
+		t.Errorf("Expected %d bytes, got %d", len(testFile), w.Body.Len())
+	}
+	if w.Header().Get("Repr-Digest") == "" {
+		t.Error("Expected Repr-Digest header")
+	}
+
+	// The final allowed download destroys the buffer straight away
+	if ob.Store.Get("testinglimit") != nil {
+		t.Error("Expected buffer to be destroyed after its final download")
+	}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(t, "GET", "/testinglimit", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected response code %v, got %v", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestDownloadNotBefore(t *testing.T) {
+	ob := Onionbox{Store: onionstore.NewStore()}
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	oBuf := &onionbuffer.OnionBuffer{Name: "testingembargo", Bytes: append([]byte(nil), testFile...)}
+	oBuf.Checksum, _ = oBuf.GetChecksum()
+	if err := oBuf.SetNotBefore(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.Store.Add(oBuf); err != nil && err.Error() != "invalid argument" {
+		t.Fatal(err)
+	}
+
+	handler := http.HandlerFunc(ob.Router)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(t, "GET", "/testingembargo", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected response code %v, got %v", http.StatusForbidden, w.Code)
+	}
+	if ob.Store.Get("testingembargo") == nil {
+		t.Error("Expected embargoed buffer to remain in the store")
+	}
+
+	// Browsers are shown when the share becomes available
+	r := newRequest(t, "GET", "/testingembargo", nil)
+	r.Header.Set("Accept", "text/html")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected response code %v, got %v", http.StatusOK, w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "Not available before") {
+		t.Error("Expected landing page to show the embargo")
+	}
+}
+
+func TestDownloadPostNotBefore(t *testing.T) {
+	ob := Onionbox{Store: onionstore.NewStore()}
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	encrypted, err := onionbuffer.Encrypt(testFile, "hunter2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	oBuf := &onionbuffer.OnionBuffer{Name: "testingembargopost", Bytes: encrypted, Encrypted: true}
+	if err := oBuf.SetNotBefore(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ob.Store.Add(oBuf); err != nil && err.Error() != "invalid argument" {
+		t.Fatal(err)
+	}
+
+	// Right or wrong, passwords get the same answer during the embargo
+	handler := http.HandlerFunc(ob.Router)
+	var bodies []string
+	for _, password := range []string{"wrong", "hunter2"} {
+		form := url.Values{formCSRF: {"testing"}, "password": {password}}
+		r := newRequest(t, "POST", "/testingembargopost", strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.AddCookie(&http.Cookie{Name: cookieCSRF, Value: "testing"})
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s: expected response code %v, got %v", password, http.StatusForbidden, w.Code)
+		}
+		bodies = append(bodies, w.Body.String())
+	}
+	if bodies[0] != bodies[1] {
+		t.Errorf("Expected the same refusal for both passwords, got %q and %q", bodies[0], bodies[1])
+	}
+}
@@ -0,0 +1,139 @@
+package onionbox
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+	"github.com/ciehanski/onionbox/onionstore"
+	"github.com/ciehanski/onionbox/templates"
+)
+
+// Errors the handlers answer clients with, beside the typed errors of
+// onionbuffer and onionstore.
+var (
+	ErrPageNotFound     = errors.New("page not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	// ErrInvalidCSRF is returned when a form's CSRF token is missing or
+	// does not match its cookie.
+	ErrInvalidCSRF = errors.New("invalid CSRF token")
+	// ErrDraining is returned for uploads once shutdown has begun.
+	ErrDraining = errors.New("server is shutting down")
+	// ErrTooManyRequests is returned when a client is over its RateLimits.
+	ErrTooManyRequests = errors.New("too many requests")
+)
+
+// clientError is a mistake of the client's, such as a malformed form field,
+// whose message is safe to show it.
+type clientError struct {
+	status  int
+	message string
+	err     error
+}
+
+func (e *clientError) Error() string {
+	if e.err == nil {
+		return e.message
+	}
+	return e.message + " " + e.err.Error()
+}
+
+func (e *clientError) Unwrap() error { return e.err }
+
+// badRequest returns a 400 error showing the client message, wrapping err.
+func badRequest(message string, err error) error {
+	return &clientError{status: http.StatusBadRequest, message: message, err: err}
+}
+
+// errorStatuses maps the errors clients may see to their status and the
+// message shown to them.
+var errorStatuses = []struct {
+	err     error
+	status  int
+	message string
+}{
+	{ErrPageNotFound, http.StatusNotFound, "Page not found."},
+	{onionstore.ErrNotFound, http.StatusNotFound, "File not found."},
+	{ErrMethodNotAllowed, http.StatusMethodNotAllowed, "Invalid HTTP Method."},
+	{ErrInvalidCSRF, http.StatusForbidden, "Invalid form token, please reload the page and try again."},
+	{onionbuffer.ErrBadPassword, http.StatusForbidden, "Wrong password."},
+	{onionbuffer.ErrNotYetAvailable, http.StatusForbidden, "Share is not available yet."},
+	{onionbuffer.ErrExpired, http.StatusGone, "Share has expired."},
+	{onionbuffer.ErrDestroyed, http.StatusGone, "Share has expired."},
+	{onionbuffer.ErrLimitReached, http.StatusGone, "Download limit reached."},
+	{onionbuffer.ErrUnknownArchiveFormat, http.StatusBadRequest, "Invalid archive format."},
+	{onionbuffer.ErrSingleFileOnly, http.StatusBadRequest, "Only a single file can be shared without an archive."},
+	{onionbuffer.ErrReservedName, http.StatusBadRequest, "A file may not be named " + onionbuffer.ChecksumsFile + " in an archive."},
+	{onionbuffer.ErrInvalidLifetime, http.StatusBadRequest, "Share would expire before it becomes available."},
+	{ErrTooManyRequests, http.StatusTooManyRequests, "Too many requests, please try again later."},
+	{onionstore.ErrQuotaExceeded, http.StatusInsufficientStorage, "Not enough memory left to store your files."},
+	{ErrDraining, http.StatusServiceUnavailable, "Server is shutting down, uploads are closed."},
+}
+
+// errorStatus returns the status and message err is shown to a client with.
+// Errors not known to be the client's are internal, and only ever shown as
+// a 500 with a generic message so their details cannot leak.
+func errorStatus(err error) (int, string) {
+	var ce *clientError
+	if errors.As(err, &ce) {
+		return ce.status, ce.message
+	}
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return http.StatusRequestEntityTooLarge, "Upload is too large."
+	}
+	for _, s := range errorStatuses {
+		if errors.Is(err, s.err) {
+			return s.status, s.message
+		}
+	}
+	return http.StatusInternalServerError, "Something went wrong, please try again."
+}
+
+// shareUnavailable reports whether err tells a share cannot be downloaded,
+// which Indistinguishable mode reports as missing.
+func shareUnavailable(err error) bool {
+	return errors.Is(err, onionbuffer.ErrExpired) ||
+		errors.Is(err, onionbuffer.ErrDestroyed) ||
+		errors.Is(err, onionbuffer.ErrLimitReached) ||
+		errors.Is(err, onionbuffer.ErrNotYetAvailable)
+}
+
+// httpError answers the client with err, as a styled page for browsers,
+// JSON for API clients and plain text otherwise.
+func (ob *Onionbox) httpError(w http.ResponseWriter, r *http.Request, err error) {
+	if ob.Indistinguishable && shareUnavailable(err) {
+		err = onionstore.ErrNotFound
+	}
+	if ob.Indistinguishable && errors.Is(err, onionstore.ErrNotFound) {
+		waitIndistinguishable(r)
+	}
+	status, message := errorStatus(err)
+	switch {
+	case wantsJSON(r):
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(status)
+		if err := json.NewEncoder(w).Encode(map[string]interface{}{"error": message, "status": status}); err != nil {
+			ob.logger().Warn("Error writing to client", "err", err)
+		}
+	case wantsHTML(r):
+		t, err := template.New("error").Parse(templates.ErrorHTML) // Parse template
+		if err != nil {
+			ob.logger().Error("Error parsing template", "err", err)
+			http.Error(w, message, status)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(status)
+		data := map[string]interface{}{"Status": status, "StatusText": http.StatusText(status), "Message": message}
+		if err := t.Execute(w, data); err != nil { // Execute template
+			ob.logger().Warn("Error executing template", "err", err)
+		}
+	default:
+		http.Error(w, message, status)
+	}
+}
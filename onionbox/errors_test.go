@@ -0,0 +1,100 @@
+package onionbox
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+	"github.com/ciehanski/onionbox/onionstore"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{onionstore.ErrNotFound, http.StatusNotFound},
+		{onionbuffer.ErrLimitReached, http.StatusGone},
+		{onionbuffer.ErrExpired, http.StatusGone},
+		{onionbuffer.ErrDestroyed, http.StatusGone},
+		{onionbuffer.ErrBadPassword, http.StatusForbidden},
+		{onionstore.ErrQuotaExceeded, http.StatusInsufficientStorage},
+		{fmt.Errorf("%w: no cookie", ErrInvalidCSRF), http.StatusForbidden},
+		{badRequest("Invalid download limit.", errors.New("strconv")), http.StatusBadRequest},
+		{&http.MaxBytesError{Limit: 1}, http.StatusRequestEntityTooLarge},
+		{onionbuffer.ErrCiphertextTooShort, http.StatusInternalServerError},
+		{errors.New("mlock /dev/shm: invalid argument"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if status, _ := errorStatus(tt.err); status != tt.status {
+			t.Errorf("%v: expected %v, got %v", tt.err, tt.status, status)
+		}
+	}
+}
+
+func TestHTTPError(t *testing.T) {
+	ob := &Onionbox{}
+	internal := errors.New("mlock /dev/shm: invalid argument")
+	tests := []struct {
+		accept      string
+		contentType string
+	}{
+		{"application/json", "application/json"},
+		{"text/html,application/xhtml+xml", "text/html"},
+		{"*/*", "text/plain"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			ob.httpError(w, r, internal)
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("Expected response code %v, got %v", http.StatusInternalServerError, w.Code)
+			}
+			if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, tt.contentType) {
+				t.Errorf("Expected %s, got %s", tt.contentType, ct)
+			}
+			if strings.Contains(w.Body.String(), "mlock") {
+				t.Errorf("Expected internal details to be hidden, got %q", w.Body.String())
+			}
+		})
+	}
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	ob.httpError(w, r, onionbuffer.ErrLimitReached)
+	var body struct {
+		Error  string
+		Status int
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Status != http.StatusGone || body.Error != "Download limit reached." {
+		t.Errorf("Unexpected JSON error %+v", body)
+	}
+}
+
+func TestHTTPErrorIndistinguishable(t *testing.T) {
+	ob := &Onionbox{Indistinguishable: true}
+	// Shares found but refused when claimed take as long as missing ones
+	for _, err := range []error{onionbuffer.ErrExpired, onionbuffer.ErrDestroyed, onionbuffer.ErrLimitReached, onionbuffer.ErrNotYetAvailable} {
+		start := time.Now()
+		w := httptest.NewRecorder()
+		ob.httpError(w, httptest.NewRequest("GET", "/", nil), err)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%v: expected %v, got %v", err, http.StatusNotFound, w.Code)
+		}
+		if took := time.Since(start); took < indistinguishableDelay {
+			t.Errorf("%v: expected at least %v, took %v", err, indistinguishableDelay, took)
+		}
+	}
+}
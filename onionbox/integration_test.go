@@ -0,0 +1,203 @@
+package onionbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+	"github.com/ciehanski/onionbox/onionstore"
+)
+
+// testServer serves ob on a loopback listener, as Tor would, for a client
+// keeping cookies like a browser.
+type testServer struct {
+	t      *testing.T
+	ob     *Onionbox
+	base   string
+	client *http.Client
+}
+
+func newTestServer(t *testing.T, ob *Onionbox) *testServer {
+	if ob.Store == nil {
+		ob.Store = onionstore.NewStore()
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ob.Server = ob.NewServer()
+	go ob.Serve(ln)
+	t.Cleanup(func() { ob.Server.Close() })
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testServer{t: t, ob: ob, base: "http://" + ln.Addr().String(), client: &http.Client{Jar: jar}}
+}
+
+// csrf loads page to get a CSRF token, as a browser showing its form would.
+func (s *testServer) csrf(page string) string {
+	resp, err := s.client.Get(s.base + page)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	resp.Body.Close()
+	u, _ := url.Parse(s.base)
+	for _, c := range s.client.Jar.Cookies(u) {
+		if c.Name == cookieCSRF {
+			return c.Value
+		}
+	}
+	s.t.Fatalf("no CSRF cookie set by %s", page)
+	return ""
+}
+
+// upload uploads a single file with the upload form's fields, returning the
+// response.
+func (s *testServer) upload(name, content string, fields map[string]string) *http.Response {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField(formCSRF, s.csrf("/"))
+	mw.WriteField("archive", "none")
+	for k, v := range fields {
+		mw.WriteField(k, v)
+	}
+	fw, err := mw.CreateFormFile("files", name)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	io.WriteString(fw, content)
+	mw.Close()
+
+	req, err := http.NewRequest("POST", s.base+"/", &body)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return resp
+}
+
+// share uploads a file, returning the name of its share.
+func (s *testServer) share(name, content string, fields map[string]string) string {
+	resp := s.upload(name, content, fields)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		s.t.Fatalf("upload failed with %v: %s", resp.StatusCode, b)
+	}
+	var result struct{ URL string }
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		s.t.Fatal(err)
+	}
+	return path.Base(result.URL)
+}
+
+// get fetches page, returning its status code and body.
+func (s *testServer) get(page string) (int, string) {
+	resp, err := s.client.Get(s.base + page)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		s.t.Fatal(err)
+	}
+	return resp.StatusCode, string(b)
+}
+
+func TestIntegrationUploadDownload(t *testing.T) {
+	s := newTestServer(t, &Onionbox{OnionURL: "example"})
+	var announced string
+	s.ob.OnShare = func(url string, _ *onionbuffer.OnionBuffer) { announced = url }
+	name := s.share("hello.txt", "hello onion", nil)
+	if announced != "http://example.onion/"+name {
+		t.Errorf("expected the share to be announced, got %q", announced)
+	}
+
+	code, body := s.get("/" + name)
+	if code != http.StatusOK || body != "hello onion" {
+		t.Errorf("expected the file back, got %v and %q", code, body)
+	}
+	code, body = s.get("/" + name + "/manifest.json")
+	if code != http.StatusOK || !strings.Contains(body, "hello.txt") {
+		t.Errorf("expected the manifest to list the file, got %v and %q", code, body)
+	}
+}
+
+func TestIntegrationDownloadLimit(t *testing.T) {
+	s := newTestServer(t, &Onionbox{})
+	name := s.share("once.txt", "burn me", map[string]string{"limit_downloads": "on", "download_limit": "1"})
+	if code, body := s.get("/" + name); code != http.StatusOK || body != "burn me" {
+		t.Fatalf("expected the first download to succeed, got %v and %q", code, body)
+	}
+	if code, _ := s.get("/" + name); code == http.StatusOK {
+		t.Error("expected the share to be gone after its last download")
+	}
+	if s.ob.Store.Get(name) != nil {
+		t.Error("expected the share to be destroyed")
+	}
+}
+
+func TestIntegrationExpiry(t *testing.T) {
+	s := newTestServer(t, &Onionbox{DefaultExpiry: 200 * time.Millisecond})
+	name := s.share("brief.txt", "soon gone", nil)
+	if code, _ := s.get("/" + name); code != http.StatusOK {
+		t.Fatalf("expected the share before its expiry, got %v", code)
+	}
+	time.Sleep(300 * time.Millisecond)
+	if code, _ := s.get("/" + name); code != http.StatusNotFound && code != http.StatusGone {
+		t.Errorf("expected the share to have expired, got %v", code)
+	}
+}
+
+func TestIntegrationEncrypted(t *testing.T) {
+	s := newTestServer(t, &Onionbox{})
+	name := s.share("secret.txt", "attack at dawn", map[string]string{"password_enabled": "on", "password": "hunter2"})
+
+	download := func(password string) (int, string) {
+		form := url.Values{formCSRF: {s.csrf("/" + name)}, "password": {password}}
+		resp, err := s.client.PostForm(s.base+"/"+name, form)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, string(b)
+	}
+	if code, body := download("wrong"); code != http.StatusForbidden || strings.Contains(body, "attack at dawn") {
+		t.Errorf("expected a wrong password to be refused, got %v and %q", code, body)
+	}
+	if code, body := download("hunter2"); code != http.StatusOK || body != "attack at dawn" {
+		t.Errorf("expected the decrypted file, got %v and %q", code, body)
+	}
+}
+
+func TestIntegrationLimits(t *testing.T) {
+	s := newTestServer(t, &Onionbox{MaxUploadSize: 1 << 10, RateLimits: RateLimits{Requests: 0.1, RequestBurst: 3}})
+	resp := s.upload("large.bin", strings.Repeat("x", 2<<10), nil)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected an upload over the maximum size to be refused, got %v", resp.StatusCode)
+	}
+	// The upload took two of the three requests, its form and its post
+	s.get("/")
+	if code, _ := s.get("/"); code != http.StatusTooManyRequests {
+		t.Errorf("expected requests over budget to be refused, got %v", code)
+	}
+}
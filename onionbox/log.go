@@ -0,0 +1,95 @@
+package onionbox
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"sync"
+)
+
+// Keys of log attributes which identify a share or a recipient. Their values
+// are redacted unless forensic logging is enabled.
+const (
+	LogKeyShare     = "share"
+	LogKeyFile      = "file"
+	LogKeyUserAgent = "user_agent"
+	LogKeyCircuit   = "circuit"
+)
+
+// NewLogger returns a leveled logger writing JSON records to w. Unless
+// forensic is set, sensitive attributes are replaced with a keyed hash which
+// is stable for the life of the process: log lines about the same share can
+// still be correlated, but the share cannot be recovered from them or found
+// by hashing guessed names.
+func NewLogger(w io.Writer, level slog.Leveler, forensic bool) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if !forensic {
+		key := make([]byte, sha256.Size)
+		if _, err := rand.Read(key); err != nil {
+			panic(err) // crypto/rand never fails on supported platforms
+		}
+		opts.ReplaceAttr = func(_ []string, a slog.Attr) slog.Attr {
+			switch a.Key {
+			case LogKeyShare, LogKeyFile, LogKeyUserAgent, LogKeyCircuit:
+				mac := hmac.New(sha256.New, key)
+				mac.Write([]byte(a.Value.String()))
+				return slog.String(a.Key, "h:"+hex.EncodeToString(mac.Sum(nil)[:8]))
+			}
+			return a
+		}
+	}
+	return slog.New(slog.NewJSONHandler(w, opts))
+}
+
+// logger returns ob's Logger, or one discarding every record if none was
+// configured.
+func (ob *Onionbox) logger() *slog.Logger {
+	if ob.Logger == nil {
+		return discardLogger
+	}
+	return ob.Logger
+}
+
+var discardLogger = slog.New(discardHandler{})
+
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
+
+// logWriter is an io.Writer logging every line written to it, so that
+// output of other components such as Tor goes through the same logger.
+type logWriter struct {
+	mu     sync.Mutex
+	logger *slog.Logger
+	level  slog.Level
+	buf    []byte
+}
+
+func newLogWriter(logger *slog.Logger, level slog.Level, component string) *logWriter {
+	return &logWriter{logger: logger.With("component", component), level: level}
+}
+
+func (lw *logWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	lw.buf = append(lw.buf, p...)
+	for {
+		i := bytes.IndexByte(lw.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if line := bytes.TrimSpace(lw.buf[:i]); len(line) > 0 {
+			lw.logger.Log(context.Background(), lw.level, string(line))
+		}
+		lw.buf = lw.buf[i+1:]
+	}
+	return len(p), nil
+}
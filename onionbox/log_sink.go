@@ -0,0 +1,137 @@
+package onionbox
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// LogSink is where onionbox writes its logs.
+type LogSink string
+
+const (
+	// LogSinkNone discards every record.
+	LogSinkNone LogSink = "none"
+	// LogSinkStderr writes records to standard error. It is the default,
+	// leaving nothing on disk.
+	LogSinkStderr LogSink = "stderr"
+	// LogSinkRing keeps the most recent records in memory, readable from
+	// the admin interface.
+	LogSinkRing LogSink = "ring"
+	// LogSinkSyslog sends records to the local syslog daemon.
+	LogSinkSyslog LogSink = "syslog"
+	// LogSinkFile writes records to a rotating file at LogFile.
+	LogSinkFile LogSink = "file"
+)
+
+// DefaultLogRingSize is the number of records kept by LogSinkRing unless
+// configured otherwise.
+const DefaultLogRingSize = 1000
+
+var (
+	ErrUnknownLogSink = errors.New("unknown log sink")
+	ErrNoLogFile      = errors.New("the file log sink needs a log file path")
+)
+
+// ParseLogSink parses a log sink chosen by the operator. An empty value
+// defaults to stderr.
+func ParseLogSink(s string) (LogSink, error) {
+	switch sink := LogSink(s); sink {
+	case "":
+		return LogSinkStderr, nil
+	case LogSinkNone, LogSinkStderr, LogSinkRing, LogSinkSyslog, LogSinkFile:
+		return sink, nil
+	default:
+		return "", ErrUnknownLogSink
+	}
+}
+
+// SetupLogging opens ob's LogSink and points ob's Logger at it.
+func (ob *Onionbox) SetupLogging() error {
+	var out io.Writer
+	switch ob.LogSink {
+	case LogSinkNone:
+		out = io.Discard
+	case LogSinkStderr, "":
+		out = os.Stderr
+	case LogSinkRing:
+		size := ob.LogRingSize
+		if size <= 0 {
+			size = DefaultLogRingSize
+		}
+		ob.logRing = NewLogRing(size)
+		out = ob.logRing
+	case LogSinkSyslog:
+		w, err := openSyslog()
+		if err != nil {
+			return err
+		}
+		out = w
+	case LogSinkFile:
+		if ob.LogFile == "" {
+			return ErrNoLogFile
+		}
+		out = &lumberjack.Logger{
+			Filename:   ob.LogFile,
+			MaxSize:    10, // megabytes
+			MaxBackups: 3,
+			MaxAge:     28, // days
+			Compress:   true,
+		}
+	default:
+		return ErrUnknownLogSink
+	}
+	ob.Logger = NewLogger(out, ob.LogLevel, ob.LogForensic)
+	return nil
+}
+
+// LogRing is an io.Writer keeping the most recent log records in memory.
+// Every Write is expected to be a single record, as written by slog's
+// handlers.
+type LogRing struct {
+	mu      sync.Mutex
+	records [][]byte
+	next    int
+	full    bool
+}
+
+// NewLogRing returns a LogRing holding up to size records.
+func NewLogRing(size int) *LogRing {
+	return &LogRing{records: make([][]byte, size)}
+}
+
+func (l *LogRing) Write(p []byte) (int, error) {
+	record := append([]byte(nil), p...) // p may be reused by the caller
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.records[l.next] = record
+	l.next = (l.next + 1) % len(l.records)
+	if l.next == 0 {
+		l.full = true
+	}
+	return len(p), nil
+}
+
+// WriteTo writes the records held, oldest first, to w.
+func (l *LogRing) WriteTo(w io.Writer) (int64, error) {
+	l.mu.Lock()
+	records := append([][]byte(nil), l.records[l.next:]...)
+	if !l.full {
+		records = nil
+	}
+	records = append(records, l.records[:l.next]...)
+	l.mu.Unlock()
+
+	var n int64
+	for _, record := range records {
+		written, err := w.Write(record)
+		n += int64(written)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,48 @@
+package onionbox
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseLogSink(t *testing.T) {
+	tests := []struct {
+		in   string
+		want LogSink
+		err  error
+	}{
+		{"", LogSinkStderr, nil},
+		{"ring", LogSinkRing, nil},
+		{"file", LogSinkFile, nil},
+		{"disk", "", ErrUnknownLogSink},
+	}
+	for _, tt := range tests {
+		got, err := ParseLogSink(tt.in)
+		if got != tt.want || err != tt.err {
+			t.Errorf("ParseLogSink(%q) = %q, %v; want %q, %v", tt.in, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestSetupLoggingFileNeedsPath(t *testing.T) {
+	ob := Onionbox{LogSink: LogSinkFile}
+	if err := ob.SetupLogging(); err != ErrNoLogFile {
+		t.Errorf("expected %v, got %v", ErrNoLogFile, err)
+	}
+}
+
+func TestLogRing(t *testing.T) {
+	ring := NewLogRing(3)
+	for _, record := range []string{"1\n", "2\n", "3\n", "4\n", "5\n"} {
+		if _, err := ring.Write([]byte(record)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	out := new(bytes.Buffer)
+	if _, err := ring.WriteTo(out); err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "3\n4\n5\n" {
+		t.Errorf("expected the 3 most recent records, got %q", out)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !windows && !plan9
+
+package onionbox
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// openSyslog connects to the local syslog daemon.
+func openSyslog() (io.Writer, error) {
+	return syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, "onionbox")
+}
@@ -0,0 +1,63 @@
+package onionbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewLoggerRedacts(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := NewLogger(buf, slog.LevelInfo, false)
+	logger.Info("Final download completed", LogKeyShare, "sillyname", LogKeyUserAgent, "Mozilla/5.0", LogKeyCircuit, "4242")
+	logger.Info("Final download completed", LogKeyShare, "sillyname")
+	logger.Debug("Request received", LogKeyShare, "sillyname")
+
+	if strings.Contains(buf.String(), "sillyname") || strings.Contains(buf.String(), "Mozilla") {
+		t.Fatalf("expected sensitive attributes to be redacted, got %s", buf)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records at info level, got %d", len(lines))
+	}
+	var first, second map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatal(err)
+	}
+	if circuit, _ := first[LogKeyCircuit].(string); !strings.HasPrefix(circuit, "h:") {
+		t.Errorf("expected the circuit to be redacted, got %v", first[LogKeyCircuit])
+	}
+	// The same share hashes the same way within a process
+	if first[LogKeyShare] != second[LogKeyShare] {
+		t.Errorf("expected stable share hash, got %v and %v", first[LogKeyShare], second[LogKeyShare])
+	}
+}
+
+func TestNewLoggerForensic(t *testing.T) {
+	buf := new(bytes.Buffer)
+	logger := NewLogger(buf, slog.LevelInfo, true)
+	logger.Info("Final download completed", LogKeyShare, "sillyname")
+	if !strings.Contains(buf.String(), `"share":"sillyname"`) {
+		t.Errorf("expected share in the clear, got %s", buf)
+	}
+}
+
+func TestLogWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	lw := newLogWriter(NewLogger(buf, slog.LevelDebug, false), slog.LevelDebug, "tor")
+	if _, err := lw.Write([]byte("Bootstrapped 5%\nBootstrapped")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := lw.Write([]byte(" 100%\n")); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"msg":"Bootstrapped 100%"`) || !strings.Contains(lines[1], `"component":"tor"`) {
+		t.Errorf("unexpected log output %s", buf)
+	}
+}
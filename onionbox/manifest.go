@@ -0,0 +1,50 @@
+package onionbox
+
+import (
+	"net/http"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+)
+
+const (
+	manifestFile  = "manifest.json"
+	signatureFile = "manifest.sig"
+)
+
+// manifest serves a share's manifest, or its detached signature when the
+// operator has configured a signing key. Password protected shares do not
+// list their files since the names would otherwise leak without the
+// password; the signed archive checksum still covers them through the
+// archive's SHA256SUMS file. Embargoed shares hide them until they become
+// available.
+func (ob *Onionbox) manifest(w http.ResponseWriter, r *http.Request, oBuffer *onionbuffer.OnionBuffer, file string) {
+	if r.Method != http.MethodGet {
+		ob.httpError(w, r, ErrMethodNotAllowed)
+		return
+	}
+	if file == signatureFile && ob.SigningKey == nil {
+		ob.httpError(w, r, ErrPageNotFound)
+		return
+	}
+
+	m := oBuffer.Manifest()
+	if oBuffer.Encrypted || oBuffer.Available() != nil {
+		m.Files = nil
+	}
+	data, err := m.Marshal()
+	if err != nil {
+		ob.logger().Error("Error marshalling manifest", "err", err)
+		ob.httpError(w, r, err)
+		return
+	}
+
+	if file == signatureFile {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		data = onionbuffer.SignManifest(data, ob.SigningKey)
+	} else {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	}
+	if _, err := w.Write(data); err != nil {
+		ob.logger().Warn("Error writing to client", "err", err)
+	}
+}
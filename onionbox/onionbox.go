@@ -2,22 +2,28 @@ package onionbox
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/md5"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
-	"os"
 	"runtime"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/cretz/bine/tor"
-	"github.com/ipsn/go-libtor"
+	toreddsa "github.com/cretz/bine/torutil/ed25519"
+	xed25519 "golang.org/x/crypto/ed25519"
 	"golang.org/x/sys/unix"
-	"gopkg.in/natefinch/lumberjack.v2"
 
+	"github.com/ciehanski/onionbox/onionbuffer"
 	"github.com/ciehanski/onionbox/onionstore"
 )
 
@@ -26,6 +32,31 @@ const (
 	cookieCSRF = "X-CSRF-Token"
 )
 
+// FileDownloadPolicy decides how downloading a single file out of a share
+// counts toward the share's download limit.
+type FileDownloadPolicy string
+
+const (
+	// FileDownloadsCount counts every single file download as a download
+	// of the whole share.
+	FileDownloadsCount FileDownloadPolicy = "count"
+	// FileDownloadsFree never counts single file downloads, only downloads
+	// of the whole share.
+	FileDownloadsFree FileDownloadPolicy = "free"
+)
+
+// FailedDownloadPolicy decides what happens to the download slot of a
+// transfer which fails part way through.
+type FailedDownloadPolicy string
+
+const (
+	// FailedDownloadsKeep counts failed transfers as downloads, so a
+	// recipient cannot read part of a share without using up a download.
+	FailedDownloadsKeep FailedDownloadPolicy = "keep"
+	// FailedDownloadsRelease gives the slot back so the recipient can retry.
+	FailedDownloadsRelease FailedDownloadPolicy = "release"
+)
+
 type Onionbox struct {
 	OnionURL    string
 	RemotePort  int
@@ -33,75 +64,209 @@ type Onionbox struct {
 	TorVersion3 bool
 	TorrcFile   string
 	Store       *onionstore.OnionStore
-	Logger      *log.Logger
+	Logger      *slog.Logger
 	Server      *http.Server
 	Debug       bool
+	// Bridges are bridge lines Tor connects through instead of public
+	// relays, and TransportPlugins map the pluggable transports they use,
+	// such as obfs4, to the path of their plugin binary.
+	Bridges          []string
+	TransportPlugins map[string]string
+	// TorBackend provides Tor, by default the embedded one.
+	TorBackend TorBackend
+	// LogLevel is the minimum level of records logged. LogForensic logs
+	// share IDs, file names, user agents and circuits in the clear.
+	LogLevel    slog.Level
+	LogForensic bool
+	// LogSink is where logs are written. LogFile is the path of the
+	// LogSinkFile sink and LogRingSize the capacity of the LogSinkRing one.
+	LogSink     LogSink
+	LogFile     string
+	LogRingSize int
+	logRing     *LogRing
+	// SigningKey, if set, signs every share's manifest. With
+	// SigningKeyIsOnionKey it also becomes the onion service's identity
+	// key, giving the operator a persistent onion address.
+	SigningKey           ed25519.PrivateKey
+	SigningKeyIsOnionKey bool
+	FileDownloadPolicy   FileDownloadPolicy
+	FailedDownloadPolicy FailedDownloadPolicy
+	// DefaultExpiry and DefaultDownloadLimit apply to shares uploaded
+	// without an expiry or a download limit. Zero disables them.
+	DefaultExpiry        time.Duration
+	DefaultDownloadLimit int64
+	// Indistinguishable answers every share which cannot be downloaded,
+	// whether it never existed, expired, ran out of downloads or is not
+	// available yet, with the same 404 after the same constant time
+	// lookup, so probing does not tell which shares once existed.
+	Indistinguishable bool
+	// MaxUploadSize caps the size of a single upload request in bytes.
+	// Zero means no limit.
+	MaxUploadSize int64
+	// ReadHeaderTimeout, ReadTimeout, WriteTimeout and IdleTimeout are the
+	// server's timeouts, zero disabling them. Uploads and downloads get
+	// ReadTimeout or WriteTimeout plus the time their size takes at
+	// MinThroughput bytes per second, and are cut off as soon as they stall
+	// or fall below MinThroughput; zero MinThroughput disables that floor.
+	ReadHeaderTimeout time.Duration
+	ReadTimeout       time.Duration
+	WriteTimeout      time.Duration
+	IdleTimeout       time.Duration
+	MinThroughput     int64
+	// Banner is an operator message shown on the upload page.
+	Banner string
+	// OnShare, if set, is called with the URL of every share uploaded.
+	OnShare func(url string, b *onionbuffer.OnionBuffer)
+	// PublishTimeout bounds how long Init waits for Tor to bootstrap and
+	// publish the onion service. Zero waits until Init's context is done.
+	PublishTimeout time.Duration
+	// OnBootstrap, if set, is called as Tor reports startup progress.
+	OnBootstrap func(status BootstrapStatus)
+	bootstrap   BootstrapStatus
+	bootstrapMu sync.Mutex
+	// Name tells the onion service apart in logs when a process publishes
+	// several sharing one Tor instance.
+	Name string
+	// Routes are the endpoints served, DefaultRoutes if empty. SiteDir is
+	// the directory served by RouteSite.
+	Routes  []Route
+	SiteDir string
+	// ClientAuth holds the x25519 public keys of the only clients allowed
+	// to reach the onion service. Empty lets anyone with the address in.
+	ClientAuth []string
+	// ShareVia, if set, is the onion service whose address links shares
+	// uploaded here, such as a private download service sharing the store.
+	ShareVia *Onionbox
+	// OnionKey, if set, is the onion service's identity key, giving it a
+	// persistent address. It takes precedence over SigningKeyIsOnionKey.
+	OnionKey ed25519.PrivateKey
+	// DoSDefenses are the Tor mitigations the onion service is published
+	// with against floods.
+	DoSDefenses DoSDefenses
+	// RateLimits are the budgets of requests, upload bytes and concurrent
+	// downloads each client gets.
+	RateLimits RateLimits
+	// ProxyProtocol publishes the onion service exporting circuit IDs,
+	// which rate limits then key on, and requires the PROXY protocol
+	// header Tor sends them in ahead of every connection.
+	ProxyProtocol bool
+	// draining is set once Shutdown begins, to refuse new uploads.
+	draining atomic.Bool
 }
 
+// Init starts Tor and publishes the onion service on it. The service is
+// served by calling Server.Serve with the returned listener.
 func (ob *Onionbox) Init(ctx context.Context) (*tor.Tor, *tor.OnionService, error) {
+	t, err := ob.StartTor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	onionSvc, err := ob.Publish(ctx, t)
+	if err != nil {
+		t.Close()
+		return nil, nil, err
+	}
+	return t, onionSvc, nil
+}
+
+// StartTor starts the Tor instance onion services are published on.
+func (ob *Onionbox) StartTor(ctx context.Context) (*tor.Tor, error) {
 	// Disable core dumping
 	ob.disableCoreDumps()
-	// If debug is NOT enabled, write all logs to disk (instead of stdout)
-	// and rotate them when necessary.
-	var torLogger io.Writer
-	var lj io.Writer
-	if !ob.Debug {
-		if runtime.GOOS != "windows" {
-			lj = &lumberjack.Logger{
-				Filename:   "/var/log/onionbox/onionbox.log",
-				MaxSize:    10, // megabytes
-				MaxBackups: 3,
-				MaxAge:     28, // days
-				Compress:   true,
-			}
-			ob.Logger.SetOutput(lj)
-			torLogger = lj
-		} else {
-			lj = &lumberjack.Logger{
-				Filename:   "%APPDATA%/Local/onionbox/onionbox.log",
-				MaxSize:    10, // megabytes
-				MaxBackups: 3,
-				MaxAge:     28, // days
-				Compress:   true,
-			}
-			ob.Logger.SetOutput(lj)
-			torLogger = lj
-		}
-	} else {
-		ob.Logger.SetOutput(os.Stdout)
-		torLogger = os.Stderr
-	}
+	// Tor's own output goes through the same logger
+	torLogger := newLogWriter(ob.logger(), slog.LevelDebug, "tor")
 
 	// Start Tor
-	t, err := ob.startTor(torLogger)
+	fmt.Println("Starting and registering onion service, please wait...")
+	return ob.startTor(ctx, torLogger)
+}
+
+// Publish creates the onion service on t and waits for it to be reachable,
+// setting OnionURL and the Server to serve it with. Several onion services
+// can be published on the same Tor instance.
+func (ob *Onionbox) Publish(ctx context.Context, t *tor.Tor) (*tor.OnionService, error) {
+	// Report bootstrap progress and problems while the service publishes
+	stopWatching, err := ob.watchBootstrap(t)
 	if err != nil {
-		return nil, nil, err
+		ob.logger().Warn("Error watching Tor bootstrap progress", "err", err)
+		stopWatching = func() {}
+	}
+	if ob.PublishTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, ob.PublishTimeout)
+		defer cancel()
 	}
 
 	// Start listening over onion service
 	onionSvc, err := ob.listenTor(ctx, t)
+	stopWatching()
 	if err != nil {
-		return nil, nil, err
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, publishTimeoutError(ob.Bootstrap())
+		}
+		return nil, err
+	}
+	if onionSvc.ID != "" {
+		ob.OnionURL = onionSvc.ID
 	}
 
 	// Init serving
-	http.HandleFunc("/", ob.Router)
-	ob.Server = &http.Server{
-		// Tor is quite slow and depending on the size of the files being
-		// transferred, the server could timeout. I would like to keep set timeouts, but
-		// will need to find a sweet spot or enable an option for large transfers.
-		IdleTimeout:  time.Minute * 3,
-		ReadTimeout:  time.Minute * 3,
-		WriteTimeout: time.Minute * 3,
-		Handler:      nil,
+	ob.Server = ob.NewServer()
+
+	return onionSvc, nil
+}
+
+// Handler returns the onion service's HTTP handler, its routes behind its
+// rate limits. Every call returns a handler with budgets of its own.
+func (ob *Onionbox) Handler() http.Handler {
+	return ob.rateLimit(http.HandlerFunc(ob.Router))
+}
+
+// NewServer returns a server for the onion service's Handler with its
+// timeouts.
+func (ob *Onionbox) NewServer() *http.Server {
+	srv := &http.Server{
+		// Uploads and downloads re-arm the read and write deadlines as
+		// their transfer moves, see pacer.
+		ReadHeaderTimeout: ob.ReadHeaderTimeout,
+		ReadTimeout:       ob.ReadTimeout,
+		WriteTimeout:      ob.WriteTimeout,
+		IdleTimeout:       ob.IdleTimeout,
+		Handler:           ob.Handler(),
 	}
+	if ob.ProxyProtocol {
+		srv.ConnContext = ProxyConnContext
+	}
+	return srv
+}
 
-	return t, onionSvc, nil
+// Serve serves the onion service on ln until the Server is shut down. ln is
+// usually the listener Publish returns, but any will do without Tor, such
+// as in tests; the Server must then be set first, see NewServer.
+func (ob *Onionbox) Serve(ln net.Listener) error {
+	if ob.Server == nil {
+		return errors.New("onionbox has no server, publish it or set one first")
+	}
+	if ob.ProxyProtocol {
+		ln = NewProxyListener(ln)
+	}
+	return ob.Server.Serve(ln)
 }
 
-func (ob *Onionbox) startTor(logger io.Writer) (*tor.Tor, error) {
-	fmt.Println("Starting and registering onion service, please wait...")
+func (ob *Onionbox) startTor(ctx context.Context, logger io.Writer) (*tor.Tor, error) {
+	conf, err := ob.torStartConf(logger)
+	if err != nil {
+		return nil, err
+	}
+	t, err := ob.torBackend().Start(ctx, conf) // Start tor
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
 
+// torStartConf returns the configuration Tor is started with.
+func (ob *Onionbox) torStartConf(logger io.Writer) (*tor.StartConf, error) {
 	var tempDataDir string
 	if runtime.GOOS != "windows" {
 		tempDataDir = "/tmp"
@@ -109,28 +274,44 @@ func (ob *Onionbox) startTor(logger io.Writer) (*tor.Tor, error) {
 		tempDataDir = "%TEMP%"
 	}
 
-	t, err := tor.Start(nil, &tor.StartConf{ // Start tor
-		ProcessCreator:         libtor.Creator,
-		DebugWriter:            logger,
-		UseEmbeddedControlConn: true, // Since we are using embedded tor via go-libtor
-		TempDataDirBase:        tempDataDir,
-		RetainTempDataDir:      false,
-		TorrcFile:              ob.TorrcFile,
-		NoHush:                 ob.Debug,
-	})
+	// Bridges let Tor connect from networks that block it
+	extraArgs, err := ob.bridgeArgs()
 	if err != nil {
 		return nil, err
 	}
-	return t, nil
+
+	return &tor.StartConf{
+		DebugWriter:       logger,
+		TempDataDirBase:   tempDataDir,
+		RetainTempDataDir: false,
+		TorrcFile:         ob.TorrcFile,
+		ExtraArgs:         extraArgs,
+		NoHush:            ob.Debug,
+	}, nil
 }
 
 func (ob *Onionbox) listenTor(ctx context.Context, t *tor.Tor) (*tor.OnionService, error) {
+	conf := &tor.ListenConf{
+		Version3:               ob.TorVersion3,
+		RemotePorts:            []int{ob.RemotePort},
+		LocalPort:              ob.LocalPort,
+		MaxStreams:             ob.DoSDefenses.MaxStreams,
+		MaxStreamsCloseCircuit: ob.DoSDefenses.MaxStreamsCloseCircuit,
+	}
+	if key := ob.onionKey(); key != nil {
+		conf.Key = toreddsa.FromCryptoPrivateKey(xed25519.PrivateKey(key))
+	}
+	ob.logger().Info("Publishing onion service", append(ob.DoSDefenses.logAttrs(), "export_circuit_id", ob.ProxyProtocol)...)
+	// ADD_ONION services cannot export circuit IDs, and their connections
+	// would be dropped without a PROXY header
+	if ob.DoSDefenses.IntroDoS || ob.ProxyProtocol {
+		return ob.configureOnion(ctx, t, conf)
+	}
+	if len(ob.ClientAuth) > 0 || ob.DoSDefenses.PoW {
+		return ob.addOnion(ctx, t, conf)
+	}
 	// Create an onion service to listen on any port but show as 80
-	onionSvc, err := t.Listen(ctx, &tor.ListenConf{
-		Version3:    ob.TorVersion3,
-		RemotePorts: []int{ob.RemotePort},
-		LocalPort:   ob.LocalPort,
-	})
+	onionSvc, err := t.Listen(ctx, conf)
 	if err != nil {
 		return nil, err
 	}
@@ -147,20 +328,17 @@ func createCSRF() (string, error) {
 	return fmt.Sprintf("%x", hasher.Sum(nil)), nil
 }
 
-// Logf is a helper function which will utilize the Logger from ob
-// to print formatted logs.
-func (ob *Onionbox) Logf(format string, args ...interface{}) {
-	if ob.Debug {
-		ob.Logger.Printf(format, args...)
+// checkCSRF returns ErrInvalidCSRF unless the form's CSRF token matches the
+// one in its cookie.
+func checkCSRF(r *http.Request) error {
+	csrfCookie, err := r.Cookie(cookieCSRF)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCSRF, err)
 	}
-}
-
-// Quit will Quit all stored buffers and exit onionbox.
-func (ob *Onionbox) Quit() {
-	if err := ob.Store.DestroyAll(); err != nil {
-		ob.Logf("Error destroying all buffers from Store: %v", err)
+	if subtle.ConstantTimeCompare([]byte(r.FormValue(formCSRF)), []byte(csrfCookie.Value)) == 0 {
+		return fmt.Errorf("%w: form and cookie values do not match", ErrInvalidCSRF)
 	}
-	os.Exit(0)
+	return nil
 }
 
 // disableCoreDumps disables core dumps on Unix systems.
@@ -168,11 +346,11 @@ func (ob *Onionbox) Quit() {
 func (ob *Onionbox) disableCoreDumps() {
 	if runtime.GOOS != "windows" && runtime.GOOS != "darwin" {
 		if err := unix.Setrlimit(unix.RLIMIT_CORE, &unix.Rlimit{Cur: 0, Max: 0}); err != nil {
-			ob.Logf("Error disabling core dumps: %v", err)
+			ob.logger().Warn("Error disabling core dumps", "err", err)
 		}
 	} else {
 		if err := syscall.Setrlimit(syscall.RLIMIT_CORE, &syscall.Rlimit{Cur: 0, Max: 0}); err != nil {
-			ob.Logf("Error disabling core dumps: %v", err)
+			ob.logger().Warn("Error disabling core dumps", "err", err)
 		}
 	}
 }
@@ -0,0 +1,194 @@
+package onionbox
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidProxyHeader is returned when reading from a connection whose
+// PROXY protocol header is malformed.
+var ErrInvalidProxyHeader = errors.New("invalid PROXY protocol header")
+
+// ErrMissingProxyHeader is returned when reading from a connection which
+// does not start with a PROXY protocol header.
+var ErrMissingProxyHeader = errors.New("missing PROXY protocol header")
+
+const (
+	// proxyPrefix starts every PROXY protocol v1 header, and never an HTTP
+	// request.
+	proxyPrefix = "PROXY "
+	// maxProxyHeader is the longest a v1 header may be, CRLF included.
+	maxProxyHeader = 107
+)
+
+// torCircuitNet holds the fake source addresses Tor writes in PROXY headers
+// with HiddenServiceExportCircuitID haproxy. Their last 32 bits are the ID
+// of the client's circuit.
+var torCircuitNet = &net.IPNet{
+	IP:   net.ParseIP("fc00:dead:beef:4dad::"),
+	Mask: net.CIDRMask(64, 128),
+}
+
+// circuitIDKey is the context key of the Tor circuit a request came over,
+// and proxyConnKey that of the connection it came over.
+type (
+	circuitIDKey struct{}
+	proxyConnKey struct{}
+)
+
+// WithCircuitID returns a copy of ctx carrying the ID of the Tor circuit a
+// connection came over.
+func WithCircuitID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, circuitIDKey{}, id)
+}
+
+// CircuitID returns the ID of the Tor circuit a request came over, if the
+// onion service exports it.
+func CircuitID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(circuitIDKey{}).(string)
+	if !ok {
+		if pc, isProxied := ctx.Value(proxyConnKey{}).(*proxyConn); isProxied {
+			id, ok = pc.circuit(), true
+		}
+	}
+	return id, ok && id != ""
+}
+
+// NewProxyListener wraps ln to read the PROXY protocol v1 header Tor sends
+// ahead of each connection of an onion service configured with
+// HiddenServiceExportCircuitID haproxy. Connections without one are
+// dropped, since anything reaching ln without a header could otherwise
+// pass for a circuit of its choosing on the next connection. Serve the
+// listener with ProxyConnContext so handlers can get the circuit with
+// CircuitID.
+func NewProxyListener(ln net.Listener) net.Listener {
+	return proxyListener{ln}
+}
+
+type proxyListener struct {
+	net.Listener
+}
+
+// Accept hands the header over to the connection, which reads it on its
+// first read so a slow client cannot hold up the accept loop.
+func (l proxyListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &proxyConn{Conn: c, r: bufio.NewReader(c)}, nil
+}
+
+// proxyConn is a connection which must start with a PROXY protocol header.
+type proxyConn struct {
+	net.Conn
+	r         *bufio.Reader
+	once      sync.Once
+	circuitID string
+	err       error
+}
+
+func (c *proxyConn) Read(p []byte) (int, error) {
+	c.once.Do(c.readHeader)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.r.Read(p)
+}
+
+// readHeader reads and parses the connection's PROXY header, closing the
+// connection if it has none.
+func (c *proxyConn) readHeader() {
+	prefix, err := c.r.Peek(len(proxyPrefix))
+	if err != nil || string(prefix) != proxyPrefix {
+		c.err = ErrMissingProxyHeader
+		c.Conn.Close()
+		return
+	}
+	var line []byte
+	for len(line) <= maxProxyHeader {
+		b, err := c.r.ReadByte()
+		if err != nil {
+			c.err = fmt.Errorf("%w: %v", ErrInvalidProxyHeader, err)
+			return
+		}
+		if line = append(line, b); b == '\n' {
+			c.circuitID, c.err = parseProxyHeader(line)
+			return
+		}
+	}
+	c.err = fmt.Errorf("%w: longer than %d bytes", ErrInvalidProxyHeader, maxProxyHeader)
+}
+
+// circuit returns the ID of the client's circuit, reading the header first
+// if need be.
+func (c *proxyConn) circuit() string {
+	c.once.Do(c.readHeader)
+	return c.circuitID
+}
+
+// parseProxyHeader parses a PROXY protocol v1 header, returning the ID of
+// the Tor circuit it comes from, or the client's address for other proxies.
+// UNKNOWN connections have none.
+func parseProxyHeader(line []byte) (string, error) {
+	invalid := func(format string, args ...interface{}) (string, error) {
+		return "", fmt.Errorf("%w: %s", ErrInvalidProxyHeader, fmt.Sprintf(format, args...))
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return invalid("not terminated by CRLF")
+	}
+	fields := strings.Split(string(line[:len(line)-2]), " ")
+	if len(fields) < 2 || fields[0] != "PROXY" {
+		return invalid("%q", line)
+	}
+	var family int
+	switch fields[1] {
+	case "UNKNOWN":
+		return "", nil
+	case "TCP4":
+		family = 4
+	case "TCP6":
+		family = 6
+	default:
+		return invalid("unknown protocol %q", fields[1])
+	}
+	if len(fields) != 6 {
+		return invalid("expected 6 fields, got %d", len(fields))
+	}
+	var src net.IP
+	for i, addr := range fields[2:4] {
+		ip := net.ParseIP(addr)
+		if ip == nil || (ip.To4() != nil) != (family == 4) {
+			return invalid("bad TCP%d address %q", family, addr)
+		}
+		if i == 0 {
+			src = ip
+		}
+	}
+	for _, port := range fields[4:6] {
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return invalid("bad port %q", port)
+		}
+	}
+	if torCircuitNet.Contains(src) {
+		return strconv.FormatUint(uint64(binary.BigEndian.Uint32(src[12:])), 10), nil
+	}
+	return src.String(), nil
+}
+
+// ProxyConnContext is an http.Server ConnContext making the circuit of
+// connections accepted by a NewProxyListener available to CircuitID.
+func ProxyConnContext(ctx context.Context, c net.Conn) context.Context {
+	if pc, ok := c.(*proxyConn); ok {
+		return context.WithValue(ctx, proxyConnKey{}, pc)
+	}
+	return ctx
+}
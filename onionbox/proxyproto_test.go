@@ -0,0 +1,88 @@
+package onionbox
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseProxyHeader(t *testing.T) {
+	tests := []struct {
+		header  string
+		circuit string
+		valid   bool
+	}{
+		{"PROXY TCP6 fc00:dead:beef:4dad::0:1c ::1 65535 80\r\n", "28", true},
+		{"PROXY TCP6 fc00:dead:beef:4dad::1:0 ::1 65535 80\r\n", "65536", true},
+		{"PROXY TCP4 192.0.2.1 127.0.0.1 5555 80\r\n", "192.0.2.1", true},
+		{"PROXY UNKNOWN\r\n", "", true},
+		{"PROXY TCP4 192.0.2.1 127.0.0.1 5555 80\n", "", false},
+		{"PROXY TCP4 ::1 127.0.0.1 5555 80\r\n", "", false},
+		{"PROXY TCP6 fc00:dead:beef:4dad::0:1c ::1 65536 80\r\n", "", false},
+		{"PROXY TCP6 fc00:dead:beef:4dad::0:1c ::1 65535\r\n", "", false},
+		{"PROXY UDP4 192.0.2.1 127.0.0.1 5555 80\r\n", "", false},
+	}
+	for _, tt := range tests {
+		circuit, err := parseProxyHeader([]byte(tt.header))
+		if tt.valid && (err != nil || circuit != tt.circuit) {
+			t.Errorf("%q: expected circuit %q, got %q and %v", tt.header, tt.circuit, circuit, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidProxyHeader) {
+			t.Errorf("%q: expected ErrInvalidProxyHeader, got %v", tt.header, err)
+		}
+	}
+}
+
+func TestProxyListener(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := &http.Server{
+		ConnContext: ProxyConnContext,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			circuit, _ := CircuitID(r.Context())
+			io.WriteString(w, "circuit="+circuit)
+		}),
+	}
+	go srv.Serve(NewProxyListener(ln))
+	defer srv.Close()
+
+	request := "GET / HTTP/1.1\r\nHost: onionbox\r\nConnection: close\r\n\r\n"
+	tests := []struct {
+		name     string
+		header   string
+		response string
+	}{
+		{"tor circuit", "PROXY TCP6 fc00:dead:beef:4dad::0:1c ::1 65535 80\r\n", "circuit=28"},
+		{"no header", "", ""},
+		{"malformed header", "PROXY TCP4 nowhere\r\n", "400 Bad Request"},
+		{"endless header", "PROXY " + strings.Repeat("A", 200) + "\r\n", "400 Bad Request"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := net.Dial("tcp", ln.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			if _, err := io.WriteString(c, tt.header+request); err != nil {
+				t.Fatal(err)
+			}
+			// Dropped connections may be reset rather than closed
+			resp, err := io.ReadAll(c)
+			if err != nil && tt.response != "" {
+				t.Fatal(err)
+			}
+			if tt.response == "" && len(resp) > 0 {
+				t.Errorf("expected the connection to be dropped, got %q", resp)
+			}
+			if !strings.HasSuffix(string(resp), tt.response) {
+				t.Errorf("expected response ending in %q, got %q", tt.response, resp)
+			}
+		})
+	}
+}
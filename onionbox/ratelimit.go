@@ -0,0 +1,246 @@
+package onionbox
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimits are the budgets each client gets. A client is a Tor circuit
+// when the onion service exports circuit IDs, otherwise every client shares
+// one global budget. Zero disables a budget.
+type RateLimits struct {
+	// Requests is the pages viewed per second, RequestBurst of them at
+	// once. Form posts, such as password attempts, count as page views.
+	Requests     float64
+	RequestBurst int
+	// UploadBytes is the bytes uploaded per second, UploadBurst of them at
+	// once. Uploads are refused until the bytes of previous ones are
+	// paid off.
+	UploadBytes int64
+	UploadBurst int64
+	// Downloads caps the downloads in flight at once.
+	Downloads int
+}
+
+// retryDownloadsAfter is the Retry-After given to clients over their
+// download concurrency, which is freed whenever a transfer ends.
+const retryDownloadsAfter = 10 * time.Second
+
+// limiterSweepInterval is how often buckets which have refilled are
+// forgotten.
+const limiterSweepInterval = time.Minute
+
+// bucket is a token bucket. Its tokens may go negative, a debt paid off
+// before it lets anything else through.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// refill adds the tokens earned since the bucket was last used, up to burst.
+func (b *bucket) refill(now time.Time, rate, burst float64) {
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+}
+
+// wait returns how long until the bucket holds n tokens.
+func (b *bucket) wait(n, rate float64) time.Duration {
+	if b.tokens >= n {
+		return 0
+	}
+	return time.Duration((n - b.tokens) / rate * float64(time.Second))
+}
+
+// client holds one client's budgets.
+type client struct {
+	requests  bucket
+	uploads   bucket
+	downloads int
+}
+
+// limiter enforces RateLimits per client.
+type limiter struct {
+	sync.Mutex
+	limits    RateLimits
+	clients   map[string]*client
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newLimiter(limits RateLimits) *limiter {
+	return &limiter{limits: limits, clients: make(map[string]*client), now: time.Now}
+}
+
+// requestBurst and uploadBurst default to one second's worth.
+func (l *limiter) requestBurst() float64 {
+	if l.limits.RequestBurst > 0 {
+		return float64(l.limits.RequestBurst)
+	}
+	return math.Max(1, l.limits.Requests)
+}
+
+func (l *limiter) uploadBurst() float64 {
+	if l.limits.UploadBurst > 0 {
+		return float64(l.limits.UploadBurst)
+	}
+	return float64(l.limits.UploadBytes)
+}
+
+// client returns the budgets of key, creating full ones for a new client.
+// The limiter must be locked.
+func (l *limiter) client(key string, now time.Time) *client {
+	if now.Sub(l.lastSweep) > limiterSweepInterval {
+		l.sweep(now)
+	}
+	c, ok := l.clients[key]
+	if !ok {
+		c = &client{
+			requests: bucket{tokens: l.requestBurst(), last: now},
+			uploads:  bucket{tokens: l.uploadBurst(), last: now},
+		}
+		l.clients[key] = c
+	}
+	return c
+}
+
+// sweep forgets clients whose budgets are back to full, since new ones
+// start full anyway. The limiter must be locked.
+func (l *limiter) sweep(now time.Time) {
+	l.lastSweep = now
+	for key, c := range l.clients {
+		c.requests.refill(now, l.limits.Requests, l.requestBurst())
+		c.uploads.refill(now, float64(l.limits.UploadBytes), l.uploadBurst())
+		requestsFull := l.limits.Requests <= 0 || c.requests.tokens >= l.requestBurst()
+		uploadsFull := l.limits.UploadBytes <= 0 || c.uploads.tokens >= l.uploadBurst()
+		if requestsFull && uploadsFull && c.downloads == 0 {
+			delete(l.clients, key)
+		}
+	}
+}
+
+// allowRequest takes a page view from key's budget, returning how long to
+// wait if there is none left.
+func (l *limiter) allowRequest(key string) (bool, time.Duration) {
+	if l.limits.Requests <= 0 {
+		return true, 0
+	}
+	l.Lock()
+	defer l.Unlock()
+	now := l.now()
+	b := &l.client(key, now).requests
+	b.refill(now, l.limits.Requests, l.requestBurst())
+	if wait := b.wait(1, l.limits.Requests); wait > 0 {
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// allowUpload reports whether key has paid off its previous uploads,
+// returning how long to wait if not.
+func (l *limiter) allowUpload(key string) (bool, time.Duration) {
+	if l.limits.UploadBytes <= 0 {
+		return true, 0
+	}
+	l.Lock()
+	defer l.Unlock()
+	now := l.now()
+	b := &l.client(key, now).uploads
+	b.refill(now, float64(l.limits.UploadBytes), l.uploadBurst())
+	if wait := b.wait(1, float64(l.limits.UploadBytes)); wait > 0 {
+		return false, wait
+	}
+	return true, 0
+}
+
+// chargeUpload takes n uploaded bytes from key's budget, going into debt
+// if need be.
+func (l *limiter) chargeUpload(key string, n int) {
+	l.Lock()
+	defer l.Unlock()
+	now := l.now()
+	b := &l.client(key, now).uploads
+	b.refill(now, float64(l.limits.UploadBytes), l.uploadBurst())
+	b.tokens -= float64(n)
+}
+
+// acquireDownload reserves one of key's concurrent downloads. The returned
+// func gives it back.
+func (l *limiter) acquireDownload(key string) (func(), bool) {
+	if l.limits.Downloads <= 0 {
+		return func() {}, true
+	}
+	l.Lock()
+	defer l.Unlock()
+	c := l.client(key, l.now())
+	if c.downloads >= l.limits.Downloads {
+		return nil, false
+	}
+	c.downloads++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.Lock()
+			c.downloads--
+			l.Unlock()
+		})
+	}, true
+}
+
+// uploadBody charges the bytes read from an upload to its client.
+type uploadBody struct {
+	io.ReadCloser
+	limiter *limiter
+	key     string
+}
+
+func (b *uploadBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.limiter.chargeUpload(b.key, n)
+	}
+	return n, err
+}
+
+// rateLimit wraps the onion service's handler with its RateLimits.
+func (ob *Onionbox) rateLimit(next http.Handler) http.Handler {
+	l := newLimiter(ob.RateLimits)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, _ := CircuitID(r.Context())
+		if ok, wait := l.allowRequest(key); !ok {
+			ob.tooManyRequests(w, r, wait, "requests")
+			return
+		}
+		switch {
+		case ob.Serves(RouteSite):
+		case r.URL.Path == "/" && r.Method == http.MethodPost:
+			if ok, wait := l.allowUpload(key); !ok {
+				ob.tooManyRequests(w, r, wait, "uploads")
+				return
+			}
+			if l.limits.UploadBytes > 0 {
+				r.Body = &uploadBody{ReadCloser: r.Body, limiter: l, key: key}
+			}
+		case r.URL.Path != "/":
+			release, ok := l.acquireDownload(key)
+			if !ok {
+				ob.tooManyRequests(w, r, retryDownloadsAfter, "downloads")
+				return
+			}
+			defer release()
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// tooManyRequests answers a client over its budget with 429, telling it
+// how long to wait.
+func (ob *Onionbox) tooManyRequests(w http.ResponseWriter, r *http.Request, wait time.Duration, budget string) {
+	ob.logger().Debug("Rate limited request", "budget", budget, "retry_after", wait.String())
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	ob.httpError(w, r, ErrTooManyRequests)
+}
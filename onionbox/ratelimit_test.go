@@ -0,0 +1,115 @@
+package onionbox
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClock returns a limiter whose time only moves when advance is called.
+func fakeClock(limits RateLimits) (*limiter, func(time.Duration)) {
+	l := newLimiter(limits)
+	now := time.Unix(1e9, 0)
+	l.now = func() time.Time { return now }
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestLimiterRequests(t *testing.T) {
+	l, advance := fakeClock(RateLimits{Requests: 1, RequestBurst: 2})
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.allowRequest("circuit"); !ok {
+			t.Fatalf("request %d refused within the burst", i)
+		}
+	}
+	if ok, wait := l.allowRequest("circuit"); ok || wait != time.Second {
+		t.Errorf("expected a second's wait past the burst, got %v and %s", ok, wait)
+	}
+	if ok, _ := l.allowRequest("other"); !ok {
+		t.Error("expected another circuit to have its own budget")
+	}
+	advance(time.Second)
+	if ok, _ := l.allowRequest("circuit"); !ok {
+		t.Error("expected the budget to refill")
+	}
+}
+
+func TestLimiterUploads(t *testing.T) {
+	l, advance := fakeClock(RateLimits{UploadBytes: 100})
+	if ok, _ := l.allowUpload(""); !ok {
+		t.Fatal("first upload refused")
+	}
+	l.chargeUpload("", 300)
+	if ok, wait := l.allowUpload(""); ok || wait < 2*time.Second {
+		t.Errorf("expected to wait for the upload's debt, got %v and %s", ok, wait)
+	}
+	advance(3 * time.Second)
+	if ok, _ := l.allowUpload(""); !ok {
+		t.Error("expected the debt to be paid off")
+	}
+}
+
+func TestLimiterDownloads(t *testing.T) {
+	l, _ := fakeClock(RateLimits{Downloads: 1})
+	release, ok := l.acquireDownload("")
+	if !ok {
+		t.Fatal("first download refused")
+	}
+	if _, ok := l.acquireDownload(""); ok {
+		t.Error("expected a second concurrent download to be refused")
+	}
+	release()
+	release()
+	if l.clients[""].downloads != 0 {
+		t.Errorf("expected releasing twice to count once, got %d downloads", l.clients[""].downloads)
+	}
+	if _, ok := l.acquireDownload(""); !ok {
+		t.Error("expected the download slot to be freed")
+	}
+}
+
+func TestLimiterSweep(t *testing.T) {
+	l, advance := fakeClock(RateLimits{Requests: 1, Downloads: 1})
+	l.allowRequest("idle")
+	l.acquireDownload("busy")
+	advance(2 * limiterSweepInterval)
+	l.allowRequest("new")
+	if _, ok := l.clients["idle"]; ok {
+		t.Error("expected the refilled client to be forgotten")
+	}
+	if _, ok := l.clients["busy"]; !ok {
+		t.Error("expected the client with a download in flight to be kept")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	ob := Onionbox{RateLimits: RateLimits{Requests: 1, UploadBytes: 4}}
+	var uploaded string
+	handler := ob.rateLimit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			b, _ := io.ReadAll(r.Body)
+			uploaded = string(b)
+		}
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(t, "POST", "/", strings.NewReader("hello")))
+	if w.Code != http.StatusOK || uploaded != "hello" {
+		t.Fatalf("expected the upload to go through, got %v", w.Code)
+	}
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(t, "GET", "/", nil))
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "1" {
+		t.Errorf("expected 429 with Retry-After 1, got %v and %q", w.Code, w.Header().Get("Retry-After"))
+	}
+
+	r := newRequest(t, "POST", "/", strings.NewReader("hello"))
+	r = r.WithContext(WithCircuitID(r.Context(), "42"))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected another circuit to have its own budget, got %v", w.Code)
+	}
+}
@@ -1,31 +1,106 @@
 package onionbox
 
 import (
+	"context"
 	"net/http"
 	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+	"github.com/ciehanski/onionbox/onionstore"
 )
 
 var downloadURLreg = regexp.MustCompile(`((?:[a-z]+))`)
 
 func (ob *Onionbox) Router(w http.ResponseWriter, r *http.Request) {
+	if ob.Indistinguishable {
+		r = r.WithContext(context.WithValue(r.Context(), requestStartKey{}, time.Now()))
+	}
+	circuit, _ := CircuitID(r.Context())
+	ob.logger().Debug("Request received", "method", r.Method, LogKeyCircuit, circuit, LogKeyUserAgent, r.UserAgent())
+	// A website takes every path
+	if ob.Serves(RouteSite) {
+		ob.site(w, r)
+		return
+	}
 	// If base URL, send to upload handler
-	if r.URL.Path == "/" {
+	if r.URL.Path == "/" && ob.Serves(RouteUpload) {
 		ob.upload(w, r)
-	} else if matches := downloadURLreg.FindStringSubmatch(r.URL.Path[1:]); matches != nil {
-		if ob.Store != nil {
-			if buf := ob.Store.Get(r.URL.Path[1:]); buf != nil {
-				ob.download(w, r)
-			} else {
-				http.Error(w, "File not found", http.StatusNotFound)
+	} else if name, file, ok := splitSharePath(r.URL.Path); ok && ob.Serves(RouteDownload) {
+		buf, err := ob.findShare(name)
+		if err != nil {
+			ob.httpError(w, r, err)
+			return
+		}
+		switch file {
+		case "":
+			ob.download(w, r, buf)
+		case manifestFile, signatureFile:
+			ob.manifest(w, r, buf, file)
+		default:
+			if strings.HasPrefix(file, "files/") {
+				ob.downloadFile(w, r, buf, strings.TrimPrefix(file, "files/"))
 				return
 			}
-		} else {
-			// Do not state the store is empty to the user
-			http.Error(w, "File not found", http.StatusNotFound)
-			return
+			ob.httpError(w, r, ErrPageNotFound)
 		}
 	} else {
-		http.Error(w, "404 page not found", http.StatusNotFound)
+		ob.httpError(w, r, ErrPageNotFound)
 		return
 	}
 }
+
+// splitSharePath splits a request path into the name of the share it asks
+// for and any file requested next to it, reporting whether it could name a
+// share at all.
+func splitSharePath(path string) (name, file string, ok bool) {
+	name = strings.TrimPrefix(path, "/")
+	if downloadURLreg.FindStringSubmatch(name) == nil {
+		return "", "", false
+	}
+	if i := strings.Index(name, "/"); i != -1 {
+		name, file = name[:i], name[i+1:]
+	}
+	return name, file, true
+}
+
+// indistinguishableDelay is the least time a request answered as a missing
+// share takes in Indistinguishable mode, longer than any lookup or refused
+// claim, so missing, expired and exhausted shares all answer after the same
+// time.
+const indistinguishableDelay = 100 * time.Millisecond
+
+// requestStartKey is the context key of the time Router got a request.
+type requestStartKey struct{}
+
+// findShare looks up the named share. In Indistinguishable mode any share
+// which cannot be downloaded is reported missing.
+func (ob *Onionbox) findShare(name string) (*onionbuffer.OnionBuffer, error) {
+	if ob.Store == nil {
+		// Do not state the store is empty to the user
+		return nil, onionstore.ErrNotFound
+	}
+	if !ob.Indistinguishable {
+		return ob.Store.Find(name)
+	}
+	buf, err := ob.Store.FindConstantTime(name)
+	if err == nil {
+		err = buf.Downloadable()
+	}
+	if err != nil {
+		return nil, onionstore.ErrNotFound
+	}
+	return buf, nil
+}
+
+// waitIndistinguishable holds a missing share response back until
+// indistinguishableDelay has passed since r was received, whether the share
+// was missing from the store or refused later on.
+func waitIndistinguishable(r *http.Request) {
+	start, ok := r.Context().Value(requestStartKey{}).(time.Time)
+	if !ok {
+		start = time.Now()
+	}
+	time.Sleep(time.Until(start.Add(indistinguishableDelay)))
+}
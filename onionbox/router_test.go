@@ -1,12 +1,15 @@
 package onionbox
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/ciehanski/onionbox/onionbuffer"
 	"github.com/ciehanski/onionbox/onionstore"
@@ -38,6 +41,26 @@ func TestRouter(t *testing.T) {
 			req:          newRequest(t, "GET", "/Uglyduck", nil),
 			expectedCode: http.StatusNotFound,
 		},
+		{
+			name:         "5: Test Manifest",
+			req:          newRequest(t, "GET", "/testing_router1/manifest.json", nil),
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "6: Test Manifest Signature Without Key",
+			req:          newRequest(t, "GET", "/testing_router1/manifest.sig", nil),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "7: Test Share Unknown File",
+			req:          newRequest(t, "GET", "/testing_router1/unknown", nil),
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "8: Test Share File Out Of Range",
+			req:          newRequest(t, "GET", "/testing_router1/files/0", nil),
+			expectedCode: http.StatusNotFound,
+		},
 		//		{
 		//			name:         "5: Test Download Valid",
 		//			req:          newRequest(t, "GET", "/testing_router1", nil),
@@ -73,3 +96,79 @@ func newRequest(t *testing.T, method, url string, body io.Reader) *http.Request
 	}
 	return r
 }
+
+func TestSplitSharePath(t *testing.T) {
+	tests := []struct {
+		path, name, file string
+		ok               bool
+	}{
+		{"/share", "share", "", true},
+		{"/share/", "share", "", true},
+		{"/share/manifest.json", "share", "manifest.json", true},
+		{"/share/files/0", "share", "files/0", true},
+		{"", "", "", false},
+		{"/", "", "", false},
+		{"/7", "", "", false},
+	}
+	for _, tt := range tests {
+		name, file, ok := splitSharePath(tt.path)
+		if name != tt.name || file != tt.file || ok != tt.ok {
+			t.Errorf("%q: expected %q, %q and %v, got %q, %q and %v", tt.path, tt.name, tt.file, tt.ok, name, file, ok)
+		}
+	}
+}
+
+func TestRouterIndistinguishable(t *testing.T) {
+	store := onionstore.NewStore()
+	shares := []*onionbuffer.OnionBuffer{
+		{Name: "testingexpired", Bytes: []byte("expired")},
+		{Name: "testingexhausted", Bytes: []byte("exhausted"), DownloadLimit: 1, Downloads: 1},
+		{Name: "testingembargoed", Bytes: []byte("embargoed"), NotBefore: time.Now().Add(time.Hour)},
+	}
+	for _, b := range shares {
+		if err := store.Add(b); err != nil && err.Error() != "invalid argument" {
+			t.Fatal(err)
+		}
+	}
+	shares[0].Expire, shares[0].ExpiresAt = true, time.Now().Add(-time.Minute)
+	paths := []string{"/testingexpired", "/testingexhausted", "/testingembargoed", "/testingmissing", "/testingexhausted/manifest.json"}
+
+	get := func(ob *Onionbox, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		http.HandlerFunc(ob.Router).ServeHTTP(w, newRequest(t, "GET", path, nil))
+		return w
+	}
+	// By default each share tells why it cannot be downloaded
+	ob := &Onionbox{Store: store}
+	if a, b := get(ob, paths[0]).Code, get(ob, paths[3]).Code; a == b {
+		t.Errorf("Expected expired and missing shares to differ, both got %v", a)
+	}
+
+	ob = &Onionbox{Store: store, Indistinguishable: true}
+	for _, path := range paths {
+		start := time.Now()
+		w := get(ob, path)
+		if took := time.Since(start); took < indistinguishableDelay {
+			t.Errorf("%s: expected at least %v, took %v", path, indistinguishableDelay, took)
+		}
+		if w.Code != http.StatusNotFound || w.Body.String() != "File not found.\n" {
+			t.Errorf("%s: expected the missing share response, got %v and %q", path, w.Code, w.Body)
+		}
+	}
+}
+
+func FuzzRouter(f *testing.F) {
+	store := onionstore.NewStore()
+	_ = store.Add(&onionbuffer.OnionBuffer{Name: "testingfuzz", Bytes: []byte("hello")})
+	ob := &Onionbox{Store: store}
+	for _, path := range []string{"/", "/testingfuzz", "/testingfuzz/", "/testingfuzz/manifest.json", "/testingfuzz/files/0", "", "*", "//"} {
+		f.Add("GET", path)
+	}
+	f.Add("POST", "/testingfuzz")
+	f.Fuzz(func(t *testing.T, method, path string) {
+		// Built by hand, as servers accept paths NewRequest would refuse
+		r := &http.Request{Method: method, URL: &url.URL{Path: path}, Header: make(http.Header)}
+		r = r.WithContext(context.Background())
+		ob.Router(httptest.NewRecorder(), r)
+	})
+}
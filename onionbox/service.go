@@ -0,0 +1,508 @@
+package onionbox
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/cretz/bine/control"
+	"github.com/cretz/bine/tor"
+	toreddsa "github.com/cretz/bine/torutil/ed25519"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+)
+
+// Route is a set of endpoints an onion service serves. One process can
+// publish several onion services sharing a Tor instance, each serving its
+// own routes.
+type Route string
+
+const (
+	// RouteUpload serves the upload page and form at /.
+	RouteUpload Route = "upload"
+	// RouteDownload serves shares, their files and their manifests.
+	RouteDownload Route = "download"
+	// RouteSite serves the static website in SiteDir. It cannot be
+	// combined with the other routes, which it would shadow.
+	RouteSite Route = "site"
+)
+
+var (
+	// ErrInvalidRoutes is returned for an unknown route or a route set
+	// which cannot be served together.
+	ErrInvalidRoutes = errors.New("invalid routes")
+	// ErrInvalidClientAuthKey is returned for a client authorization key
+	// which is not a base32 x25519 public key.
+	ErrInvalidClientAuthKey = errors.New("invalid client authorization key")
+	// ErrNoTmpfs is returned when configuring an onion service with no
+	// tmpfs to hand its key to Tor through.
+	ErrNoTmpfs = errors.New("no tmpfs to keep the onion service's key on")
+)
+
+// DefaultRoutes are served by an onion service without any Routes.
+var DefaultRoutes = []Route{RouteUpload, RouteDownload}
+
+// ValidateRoutes checks routes can be served by one onion service.
+func ValidateRoutes(routes []Route) error {
+	var site, other bool
+	for _, r := range routes {
+		switch r {
+		case RouteUpload, RouteDownload:
+			other = true
+		case RouteSite:
+			site = true
+		default:
+			return fmt.Errorf("%w: unknown route %q, use upload, download or site", ErrInvalidRoutes, r)
+		}
+	}
+	if site && other {
+		return fmt.Errorf("%w: site cannot be combined with upload or download", ErrInvalidRoutes)
+	}
+	return nil
+}
+
+// Serves reports whether the onion service serves route r.
+func (ob *Onionbox) Serves(r Route) bool {
+	routes := ob.Routes
+	if len(routes) == 0 {
+		routes = DefaultRoutes
+	}
+	for _, route := range routes {
+		if route == r {
+			return true
+		}
+	}
+	return false
+}
+
+// shareOnion returns the onion address shares uploaded here are linked
+// from, which is another service's when uploads and downloads are split.
+func (ob *Onionbox) shareOnion() string {
+	if ob.ShareVia != nil {
+		return ob.ShareVia.OnionURL
+	}
+	return ob.OnionURL
+}
+
+// site serves the static website in SiteDir.
+func (ob *Onionbox) site(w http.ResponseWriter, r *http.Request) {
+	if ob.SiteDir == "" {
+		ob.httpError(w, r, ErrPageNotFound)
+		return
+	}
+	http.FileServer(http.Dir(ob.SiteDir)).ServeHTTP(w, r)
+}
+
+// ParseClientAuthKey parses the x25519 public key of a client allowed to
+// reach an onion service, either bare base32 or in the descriptor:x25519:
+// form of Tor's authorized_clients files. It returns the key in the form
+// Tor expects.
+func ParseClientAuthKey(s string) (string, error) {
+	key := strings.ToUpper(strings.TrimSpace(s))
+	key = strings.TrimPrefix(key, "DESCRIPTOR:X25519:")
+	raw, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(key)
+	if err != nil || len(raw) != 32 {
+		return "", fmt.Errorf("%w: %q is not a base32 x25519 public key", ErrInvalidClientAuthKey, s)
+	}
+	return key, nil
+}
+
+// onionKey returns the identity key of the onion service, or nil to have
+// Tor generate a new address.
+func (ob *Onionbox) onionKey() ed25519.PrivateKey {
+	if ob.OnionKey != nil {
+		return ob.OnionKey
+	}
+	if ob.SigningKey != nil && ob.SigningKeyIsOnionKey {
+		return ob.SigningKey
+	}
+	return nil
+}
+
+// addOnion creates a v3 onion service with settings bine cannot send: v3
+// client authorization, which bine only knows in its older v2 form, and
+// proof of work defenses. The service is added with a raw ADD_ONION and the
+// wait for its publication mirrors tor.Listen.
+func (ob *Onionbox) addOnion(ctx context.Context, t *tor.Tor, conf *tor.ListenConf) (*tor.OnionService, error) {
+	cmd := "ADD_ONION NEW:ED25519-V3"
+	flags := []string{"DiscardPK"}
+	if key, ok := conf.Key.(toreddsa.KeyPair); ok {
+		k := &control.ED25519Key{KeyPair: key}
+		cmd = fmt.Sprintf("ADD_ONION %s:%s", k.Type(), k.Blob())
+		flags = nil
+	}
+	if len(ob.ClientAuth) > 0 {
+		flags = append(flags, "V3Auth")
+	}
+	if conf.MaxStreamsCloseCircuit {
+		flags = append(flags, "MaxStreamsCloseCircuit")
+	}
+	if len(flags) > 0 {
+		cmd += " Flags=" + strings.Join(flags, ",")
+	}
+	if conf.MaxStreams > 0 {
+		cmd += " MaxStreams=" + strconv.Itoa(conf.MaxStreams)
+	}
+	cmd += ob.DoSDefenses.powArgs()
+	for _, client := range ob.ClientAuth {
+		key, err := ParseClientAuthKey(client)
+		if err != nil {
+			return nil, err
+		}
+		cmd += " ClientAuthV3=" + key
+	}
+
+	ln, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(conf.LocalPort))
+	if err != nil {
+		return nil, err
+	}
+	svc := &tor.OnionService{
+		Tor:                       t,
+		LocalListener:             ln,
+		CloseLocalListenerOnClose: true,
+		RemotePorts:               conf.RemotePorts,
+		Version3:                  true,
+	}
+	for _, port := range conf.RemotePorts {
+		cmd += fmt.Sprintf(" Port=%d,%s", port, ln.Addr())
+	}
+	resp, err := t.Control.SendRequest("%s", cmd)
+	if err != nil {
+		ln.Close()
+		// Older Tor rejects the arguments it does not know
+		return nil, fmt.Errorf("%w (%s)", err, ob.torRequirement())
+	}
+	for _, data := range resp.Data {
+		if id, ok := strings.CutPrefix(data, "ServiceID="); ok {
+			svc.ID = id
+		}
+	}
+	if svc.ID == "" {
+		ln.Close()
+		return nil, errors.New("tor did not return the onion service's address")
+	}
+
+	// From here on the service exists, so Close removes it on error
+	if err := ob.waitPublished(ctx, t, svc.ID); err != nil {
+		if closeErr := svc.Close(); closeErr != nil {
+			ob.logger().Warn("Error closing onion service", "err", closeErr)
+		}
+		return nil, err
+	}
+	return svc, nil
+}
+
+// configureOnion creates a v3 onion service through Tor's configuration, as
+// a torrc HiddenServiceDir would, for settings ADD_ONION cannot carry: intro
+// point rate limiting and exporting circuit IDs. onionbox creates the
+// service's directory on a tmpfs, see onionRuntimeDir, so Tor must run as
+// the same user to read it. Its identity key is only written there while
+// Tor loads it, see setOnionOptions. Other onion services configured in Tor
+// are kept, and the service is removed from the configuration again and
+// its directory wiped once it is closed.
+func (ob *Onionbox) configureOnion(ctx context.Context, t *tor.Tor, conf *tor.ListenConf) (*tor.OnionService, error) {
+	dir, err := onionRuntimeDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := ob.writeOnionDir(dir); err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(conf.LocalPort))
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	svc := &tor.OnionService{
+		Tor:                       t,
+		LocalListener:             &configuredListener{Listener: ln, remove: func() error { return removeConfiguredOnion(t, dir) }},
+		CloseLocalListenerOnClose: true,
+		RemotePorts:               conf.RemotePorts,
+		Version3:                  true,
+	}
+	onionKeys.Lock()
+	// Without a key of its own, Tor makes one up which is kept instead
+	onionKeys.files[dir] = lockKeyFile(onionKeyFile(conf))
+	err = setOnionOptions(t, func(opts []*control.KeyVal) []*control.KeyVal {
+		return append(opts, ob.torrcService(dir, ln.Addr(), conf)...)
+	})
+	if err != nil {
+		forgetKeyFile(dir)
+	}
+	onionKeys.Unlock()
+	if err != nil {
+		ln.Close()
+		os.RemoveAll(dir)
+		// Older Tor rejects the options it does not know
+		return nil, fmt.Errorf("%w (%s)", err, ob.torRequirement())
+	}
+
+	// From here on the service exists, so Close removes it on error
+	hostname, err := os.ReadFile(filepath.Join(dir, "hostname"))
+	if err == nil {
+		// Services without an ephemeral ID to delete set OnionURL here
+		ob.OnionURL = strings.TrimSuffix(strings.TrimSpace(string(hostname)), ".onion")
+		err = ob.waitPublished(ctx, t, ob.OnionURL)
+	}
+	if err != nil {
+		if closeErr := svc.Close(); closeErr != nil {
+			ob.logger().Warn("Error closing onion service", "err", closeErr)
+		}
+		return nil, err
+	}
+	return svc, nil
+}
+
+// onionRuntimeDir creates the directory of a configured onion service in a
+// private onionbox directory on a tmpfs, $XDG_RUNTIME_DIR or else /dev/shm,
+// so the keys Tor reads from it never reach a disk, and are gone with a
+// reboot should onionbox die before wiping them.
+func onionRuntimeDir() (string, error) {
+	base := os.Getenv("XDG_RUNTIME_DIR")
+	if base == "" {
+		base = "/dev/shm"
+	}
+	if !onTmpfs(base) {
+		return "", fmt.Errorf("%w: %s is not one", ErrNoTmpfs, base)
+	}
+	runtimeDir := filepath.Join(base, "onionbox")
+	if err := os.Mkdir(runtimeDir, 0700); err != nil && !errors.Is(err, fs.ErrExist) {
+		return "", err
+	}
+	// Refuse a directory left open to others
+	info, err := os.Lstat(runtimeDir)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() || info.Mode().Perm() != 0700 {
+		return "", fmt.Errorf("%s must be a directory only its owner can access", runtimeDir)
+	}
+	return os.MkdirTemp(runtimeDir, "onion-")
+}
+
+// onionKeys holds the identity key files of the onion services onionbox
+// configured, by directory, in locked memory. Tor reloads every onion
+// service whenever their options change and makes up a new key for any
+// whose directory has none, so the files are put back each time.
+var onionKeys = struct {
+	sync.Mutex
+	files map[string][]byte
+}{files: make(map[string][]byte)}
+
+// setOnionOptions sets Tor's onion service options to what change makes of
+// them. The key files in onionKeys are written to their directories only
+// while Tor loads them, and those of services configured without a key are
+// kept from what Tor made up. The caller must hold onionKeys' lock.
+func setOnionOptions(t *tor.Tor, change func([]*control.KeyVal) []*control.KeyVal) error {
+	opts, err := t.Control.GetConf("HiddenServiceOptions")
+	if err != nil {
+		return err
+	}
+	for dir, key := range onionKeys.files {
+		if key != nil && err == nil {
+			err = os.WriteFile(filepath.Join(dir, onionKeyName), key, 0600)
+		}
+	}
+	if err == nil {
+		if set := change(setOptions(opts)); len(set) > 0 {
+			err = t.Control.SetConf(set...)
+		} else {
+			// Setting no options leaves them as they are, so reset them
+			err = t.Control.ResetConf(control.NewKeyVal("HiddenServiceDir", ""))
+		}
+	}
+	// Tor has loaded the keys once the options are set, so wipe them all
+	for dir, key := range onionKeys.files {
+		path := filepath.Join(dir, onionKeyName)
+		if key == nil && err == nil {
+			key, err = os.ReadFile(path)
+			onionKeys.files[dir] = lockKeyFile(key)
+		}
+		if rmErr := os.Remove(path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+			err = errors.Join(err, rmErr)
+		}
+	}
+	return err
+}
+
+// onionKeyName is the name of the identity key file in an onion service's
+// directory.
+const onionKeyName = "hs_ed25519_secret_key"
+
+// onionKeyFile returns the identity key file of the service conf describes,
+// or nil if it has no key of its own.
+func onionKeyFile(conf *tor.ListenConf) []byte {
+	key, ok := conf.Key.(toreddsa.KeyPair)
+	if !ok {
+		return nil
+	}
+	// Tor's key file format: a header padded to 32 bytes, then the expanded
+	// secret key
+	data := make([]byte, 32, 32+len(key.PrivateKey()))
+	copy(data, "== ed25519v1-secret: type0 ==")
+	return append(data, key.PrivateKey()...)
+}
+
+// lockKeyFile keeps a key file out of SWAP, as far as it can be.
+func lockKeyFile(key []byte) []byte {
+	if len(key) > 0 {
+		_ = syscall.Mlock(key)
+	}
+	return key
+}
+
+// forgetKeyFile wipes the key file of the service in dir. The caller must
+// hold onionKeys' lock.
+func forgetKeyFile(dir string) {
+	if key := onionKeys.files[dir]; len(key) > 0 {
+		onionbuffer.Wipe(key)
+		_ = syscall.Munlock(key)
+	}
+	delete(onionKeys.files, dir)
+}
+
+// writeOnionDir fills the directory of a configured onion service with its
+// authorized clients.
+func (ob *Onionbox) writeOnionDir(dir string) error {
+	if len(ob.ClientAuth) == 0 {
+		return nil
+	}
+	clients := filepath.Join(dir, "authorized_clients")
+	if err := os.Mkdir(clients, 0700); err != nil {
+		return err
+	}
+	for i, client := range ob.ClientAuth {
+		key, err := ParseClientAuthKey(client)
+		if err != nil {
+			return err
+		}
+		auth := []byte("descriptor:x25519:" + key + "\n")
+		if err := os.WriteFile(filepath.Join(clients, fmt.Sprintf("client%d.auth", i)), auth, 0600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// torrcService returns the options configuring the onion service in dir,
+// forwarding its ports to addr.
+func (ob *Onionbox) torrcService(dir string, addr net.Addr, conf *tor.ListenConf) []*control.KeyVal {
+	opts := []*control.KeyVal{
+		control.NewKeyVal("HiddenServiceDir", dir),
+		control.NewKeyVal("HiddenServiceVersion", "3"),
+	}
+	for _, port := range conf.RemotePorts {
+		opts = append(opts, control.NewKeyVal("HiddenServicePort", fmt.Sprintf("%d %s", port, addr)))
+	}
+	if ob.ProxyProtocol {
+		opts = append(opts, control.NewKeyVal("HiddenServiceExportCircuitID", "haproxy"))
+	}
+	return append(opts, ob.DoSDefenses.torrcOptions()...)
+}
+
+// setOptions drops the keys GETCONF returns without a value, which it does
+// for option groups that are not set.
+func setOptions(opts []*control.KeyVal) []*control.KeyVal {
+	set := opts[:0:0]
+	for _, opt := range opts {
+		if opt.ValSet() {
+			set = append(set, opt)
+		}
+	}
+	return set
+}
+
+// withoutOnionDir returns the onion service options in opts without those
+// of the service in dir, which run from its HiddenServiceDir to the next.
+func withoutOnionDir(opts []*control.KeyVal, dir string) []*control.KeyVal {
+	var kept []*control.KeyVal
+	skipping := false
+	for _, opt := range opts {
+		if opt.Key == "HiddenServiceDir" {
+			skipping = opt.Val == dir
+		}
+		if !skipping {
+			kept = append(kept, opt)
+		}
+	}
+	return kept
+}
+
+// removeConfiguredOnion removes the onion service in dir from Tor's
+// configuration and wipes its directory and key.
+func removeConfiguredOnion(t *tor.Tor, dir string) error {
+	onionKeys.Lock()
+	forgetKeyFile(dir)
+	err := setOnionOptions(t, func(opts []*control.KeyVal) []*control.KeyVal {
+		return withoutOnionDir(opts, dir)
+	})
+	onionKeys.Unlock()
+	return errors.Join(err, os.RemoveAll(dir))
+}
+
+// configuredListener is the local listener of a configured onion service,
+// which has no ephemeral ID for tor.OnionService.Close to delete. Closing it
+// removes the service instead.
+type configuredListener struct {
+	net.Listener
+	remove func() error
+}
+
+func (l *configuredListener) Close() error {
+	return errors.Join(l.Listener.Close(), l.remove())
+}
+
+// torRequirement describes the Tor version the settings sent by addOnion
+// and configureOnion need.
+func (ob *Onionbox) torRequirement() string {
+	if ob.DoSDefenses.PoW {
+		return "proof of work defenses need Tor 0.4.8 or later"
+	}
+	if ob.DoSDefenses.IntroDoS {
+		return "intro point rate limiting needs Tor 0.4.2 or later, running as the same user as onionbox"
+	}
+	if ob.ProxyProtocol {
+		return "exporting circuit IDs needs Tor 0.4.0 or later, running as the same user as onionbox"
+	}
+	return "client authorization needs Tor 0.4.6 or later"
+}
+
+// waitPublished waits until a descriptor of the onion service id has been
+// uploaded to a directory, failing once every upload attempted failed.
+func (ob *Onionbox) waitPublished(ctx context.Context, t *tor.Tor, id string) error {
+	if err := t.EnableNetwork(ctx, true); err != nil {
+		return err
+	}
+	attempted, failed := 0, 0
+	_, err := t.Control.EventWait(ctx, []control.EventCode{control.EventCodeHSDesc},
+		func(evt control.Event) (bool, error) {
+			hs, _ := evt.(*control.HSDescEvent)
+			if hs == nil || hs.Address != id {
+				return false, nil
+			}
+			switch hs.Action {
+			case "UPLOAD":
+				attempted++
+			case "FAILED":
+				if failed++; failed == attempted {
+					return false, fmt.Errorf("failed all %d descriptor uploads, last reason: %s", failed, hs.Reason)
+				}
+			case "UPLOADED":
+				return true, nil
+			}
+			return false, nil
+		})
+	return err
+}
@@ -0,0 +1,277 @@
+package onionbox
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cretz/bine/control"
+	"github.com/cretz/bine/tor"
+	toreddsa "github.com/cretz/bine/torutil/ed25519"
+	xed25519 "golang.org/x/crypto/ed25519"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+	"github.com/ciehanski/onionbox/onionstore"
+)
+
+func TestValidateRoutes(t *testing.T) {
+	tests := []struct {
+		routes []Route
+		valid  bool
+	}{
+		{nil, true},
+		{[]Route{RouteUpload}, true},
+		{[]Route{RouteDownload}, true},
+		{[]Route{RouteUpload, RouteDownload}, true},
+		{[]Route{RouteSite}, true},
+		{[]Route{RouteSite, RouteDownload}, false},
+		{[]Route{"admin"}, false},
+	}
+	for _, tt := range tests {
+		err := ValidateRoutes(tt.routes)
+		if tt.valid && err != nil {
+			t.Errorf("%v: unexpected error %v", tt.routes, err)
+		}
+		if !tt.valid && !errors.Is(err, ErrInvalidRoutes) {
+			t.Errorf("%v: expected ErrInvalidRoutes, got %v", tt.routes, err)
+		}
+	}
+}
+
+func TestParseClientAuthKey(t *testing.T) {
+	const key = "G7UEEDWZWBTGPGH6MNUZQKBH4CZNWWQOFCSFIEFSCQPXK6AGBBEA"
+	for _, s := range []string{key, strings.ToLower(key), "descriptor:x25519:" + key, " " + key + "\n"} {
+		got, err := ParseClientAuthKey(s)
+		if err != nil || got != key {
+			t.Errorf("%q: expected %s, got %q and %v", s, key, got, err)
+		}
+	}
+	for _, s := range []string{"", key[:40], key + "AA", "descriptor:x25519:not-base32"} {
+		if _, err := ParseClientAuthKey(s); !errors.Is(err, ErrInvalidClientAuthKey) {
+			t.Errorf("%q: expected ErrInvalidClientAuthKey, got %v", s, err)
+		}
+	}
+}
+
+func TestRouterRoutes(t *testing.T) {
+	store := onionstore.NewStore()
+	if err := store.Add(&onionbuffer.OnionBuffer{Name: "testingroutes", Bytes: []byte("hello")}); err != nil && err.Error() != "invalid argument" {
+		t.Fatal(err)
+	}
+	site := t.TempDir()
+	if err := os.WriteFile(filepath.Join(site, "index.html"), []byte("<h1>Welcome</h1>"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		routes       []Route
+		path         string
+		expectedCode int
+	}{
+		{"upload only serves the upload page", []Route{RouteUpload}, "/", http.StatusOK},
+		{"upload only hides shares", []Route{RouteUpload}, "/testingroutes/manifest.json", http.StatusNotFound},
+		{"download only hides the upload page", []Route{RouteDownload}, "/", http.StatusNotFound},
+		{"download only serves shares", []Route{RouteDownload}, "/testingroutes/manifest.json", http.StatusOK},
+		{"site serves its index", []Route{RouteSite}, "/", http.StatusOK},
+		{"site hides shares", []Route{RouteSite}, "/testingroutes/manifest.json", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ob := &Onionbox{Store: store, Routes: tt.routes, SiteDir: site}
+			w := httptest.NewRecorder()
+			http.HandlerFunc(ob.Router).ServeHTTP(w, newRequest(t, "GET", tt.path, nil))
+			if w.Code != tt.expectedCode {
+				t.Errorf("Expected response code %v, got %v", tt.expectedCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestShareVia(t *testing.T) {
+	download := &Onionbox{OnionURL: "download", Routes: []Route{RouteDownload}}
+	upload := &Onionbox{OnionURL: "upload", Routes: []Route{RouteUpload}, ShareVia: download}
+	if got := upload.shareOnion(); got != "download" {
+		t.Errorf("expected shares linked from the download service, got %s", got)
+	}
+	if got := download.shareOnion(); got != "download" {
+		t.Errorf("expected shares linked from the service itself, got %s", got)
+	}
+}
+
+func TestWriteOnionDir(t *testing.T) {
+	const client = "G7UEEDWZWBTGPGH6MNUZQKBH4CZNWWQOFCSFIEFSCQPXK6AGBBEA"
+	ob := &Onionbox{ClientAuth: []string{client}}
+	dir := t.TempDir()
+	if err := ob.writeOnionDir(dir); err != nil {
+		t.Fatal(err)
+	}
+	auth, err := os.ReadFile(filepath.Join(dir, "authorized_clients", "client0.auth"))
+	if err != nil || string(auth) != "descriptor:x25519:"+client+"\n" {
+		t.Errorf("unexpected authorized client %q, %v", auth, err)
+	}
+	// The key is only written while Tor loads it
+	if _, err := os.Stat(filepath.Join(dir, onionKeyName)); !os.IsNotExist(err) {
+		t.Errorf("expected no key file, got %v", err)
+	}
+}
+
+func TestOnionKeyFile(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := onionKeyFile(&tor.ListenConf{Key: toreddsa.FromCryptoPrivateKey(xed25519.PrivateKey(key))})
+	if len(secret) != 96 || !strings.HasPrefix(string(secret), "== ed25519v1-secret: type0 ==\x00") {
+		t.Errorf("unexpected key file %q", secret)
+	}
+	if secret := onionKeyFile(&tor.ListenConf{}); secret != nil {
+		t.Errorf("expected no key file without a key, got %q", secret)
+	}
+}
+
+// fakeControl answers Tor control commands on conn as a Tor without any
+// onion services would, calling onSet with each SETCONF.
+func fakeControl(conn net.Conn, onSet func(cmd string)) {
+	tc := textproto.NewConn(conn)
+	for {
+		cmd, err := tc.ReadLine()
+		if err != nil {
+			return
+		}
+		switch {
+		case strings.HasPrefix(cmd, "GETCONF"):
+			tc.PrintfLine("250 HiddenServiceDir")
+		case strings.HasPrefix(cmd, "SETCONF"):
+			onSet(cmd)
+			tc.PrintfLine("250 OK")
+		default:
+			tc.PrintfLine("250 OK")
+		}
+	}
+}
+
+func TestSetOnionOptions(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	keyed, generated := t.TempDir(), t.TempDir()
+	var seen []string
+	go fakeControl(server, func(cmd string) {
+		// Tor reads the keys it has and makes up the missing ones
+		if _, err := os.Stat(filepath.Join(keyed, onionKeyName)); err == nil {
+			seen = append(seen, keyed)
+		}
+		if _, err := os.Stat(filepath.Join(generated, onionKeyName)); os.IsNotExist(err) {
+			os.WriteFile(filepath.Join(generated, onionKeyName), []byte("made up"), 0600)
+		}
+	})
+	tr := &tor.Tor{Control: control.NewConn(textproto.NewConn(client))}
+
+	onionKeys.Lock()
+	defer onionKeys.Unlock()
+	defer forgetKeyFile(keyed)
+	defer forgetKeyFile(generated)
+	onionKeys.files[keyed] = []byte("secret")
+	onionKeys.files[generated] = nil
+	add := func(opts []*control.KeyVal) []*control.KeyVal {
+		return append(opts, control.NewKeyVal("HiddenServiceDir", keyed))
+	}
+	for i := 0; i < 2; i++ {
+		if err := setOnionOptions(tr, add); err != nil {
+			t.Fatal(err)
+		}
+		for _, dir := range []string{keyed, generated} {
+			if _, err := os.Stat(filepath.Join(dir, onionKeyName)); !os.IsNotExist(err) {
+				t.Errorf("expected the key in %s to be wiped once loaded, got %v", dir, err)
+			}
+		}
+	}
+	if len(seen) != 2 {
+		t.Errorf("expected the key to be there for both loads, it was for %d", len(seen))
+	}
+	if key := onionKeys.files[generated]; string(key) != "made up" {
+		t.Errorf("expected the key Tor made up to be kept, got %q", key)
+	}
+}
+
+func TestOnionRuntimeDir(t *testing.T) {
+	// Directories on a disk are refused
+	disk := t.TempDir()
+	if onTmpfs(disk) {
+		t.Skip("temporary directory is a tmpfs")
+	}
+	t.Setenv("XDG_RUNTIME_DIR", disk)
+	if _, err := onionRuntimeDir(); !errors.Is(err, ErrNoTmpfs) {
+		t.Errorf("expected %v, got %v", ErrNoTmpfs, err)
+	}
+
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if !onTmpfs("/dev/shm") {
+		t.Skip("/dev/shm is not a tmpfs")
+	}
+	dir, err := onionRuntimeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, d := range []string{dir, filepath.Dir(dir)} {
+		info, err := os.Stat(d)
+		if err != nil || info.Mode().Perm() != 0700 {
+			t.Errorf("expected %s to be private, got %v and %v", d, info.Mode(), err)
+		}
+	}
+}
+
+func TestTorrcService(t *testing.T) {
+	ob := &Onionbox{DoSDefenses: DoSDefenses{IntroDoS: true}}
+	addr := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 8080}
+	var options []string
+	for _, opt := range ob.torrcService("/tmp/onion", addr, &tor.ListenConf{RemotePorts: []int{80}}) {
+		options = append(options, opt.Key+"="+opt.Val)
+	}
+	want := "HiddenServiceDir=/tmp/onion HiddenServiceVersion=3 HiddenServicePort=80 127.0.0.1:8080 HiddenServiceEnableIntroDoSDefense=1"
+	if got := strings.Join(options, " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	ob = &Onionbox{ProxyProtocol: true}
+	options = nil
+	for _, opt := range ob.torrcService("/tmp/onion", addr, &tor.ListenConf{RemotePorts: []int{80}}) {
+		options = append(options, opt.Key+"="+opt.Val)
+	}
+	want = "HiddenServiceDir=/tmp/onion HiddenServiceVersion=3 HiddenServicePort=80 127.0.0.1:8080 HiddenServiceExportCircuitID=haproxy"
+	if got := strings.Join(options, " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestWithoutOnionDir(t *testing.T) {
+	opts := control.KeyVals(
+		"HiddenServiceDir", "/var/lib/tor/other",
+		"HiddenServicePort", "80 127.0.0.1:80",
+		"HiddenServiceDir", "/tmp/onion",
+		"HiddenServicePort", "80 127.0.0.1:8080",
+		"HiddenServiceEnableIntroDoSDefense", "1",
+		"HiddenServiceDir", "/var/lib/tor/last",
+		"HiddenServicePort", "22 127.0.0.1:22",
+	)
+	var kept []string
+	for _, opt := range withoutOnionDir(opts, "/tmp/onion") {
+		kept = append(kept, opt.Key+"="+opt.Val)
+	}
+	want := "HiddenServiceDir=/var/lib/tor/other HiddenServicePort=80 127.0.0.1:80 HiddenServiceDir=/var/lib/tor/last HiddenServicePort=22 127.0.0.1:22"
+	if got := strings.Join(kept, " "); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	// GETCONF returns the group without a value when no service is set
+	if set := setOptions([]*control.KeyVal{{Key: "HiddenServiceOptions"}}); len(set) != 0 {
+		t.Errorf("expected no options, got %v", set)
+	}
+}
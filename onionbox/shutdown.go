@@ -0,0 +1,107 @@
+package onionbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+	"github.com/ciehanski/onionbox/onionstore"
+)
+
+// DefaultShutdownTimeout is how long in-flight transfers are given to
+// finish once shutdown begins.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// ErrShutdownForced is returned by Shutdown when transfers were still in
+// flight at its deadline and had to be cut off.
+var ErrShutdownForced = errors.New("shutdown deadline exceeded, in-flight transfers were cut off")
+
+// Shutdown stops the server gracefully: uploads are refused straight away,
+// in-flight downloads may finish until ctx is done, and then every share is
+// wiped from memory. The onion service and Tor are left for the caller to
+// close.
+func (ob *Onionbox) Shutdown(ctx context.Context) error {
+	return ShutdownAll(ctx, ob)
+}
+
+// ShutdownAll shuts down onion services published by one process together.
+// They all drain at once until ctx is done, and only then are their stores
+// and the enclave key they share wiped.
+func ShutdownAll(ctx context.Context, obs ...*Onionbox) error {
+	errs := make([]error, len(obs))
+	var wg sync.WaitGroup
+	for i, ob := range obs {
+		wg.Add(1)
+		go func(i int, ob *Onionbox) {
+			defer wg.Done()
+			errs[i] = ob.drain(ctx)
+		}(i, ob)
+	}
+	wg.Wait()
+	return errors.Join(errors.Join(errs...), wipe(obs))
+}
+
+// drain refuses new uploads and waits for in-flight requests to finish
+// until ctx is done, when the remaining connections are closed.
+func (ob *Onionbox) drain(ctx context.Context) error {
+	ob.draining.Store(true)
+	if ob.Server == nil {
+		return nil
+	}
+	err := ob.Server.Shutdown(ctx)
+	if err == nil {
+		return nil
+	}
+	if closeErr := ob.Server.Close(); closeErr != nil {
+		ob.logger().Warn("Error closing connections", "err", closeErr)
+	}
+	if err == context.DeadlineExceeded || err == context.Canceled {
+		return ErrShutdownForced
+	}
+	return err
+}
+
+// Wipe destroys every share in the store along with the enclave key.
+func (ob *Onionbox) Wipe() error {
+	return wipe([]*Onionbox{ob})
+}
+
+// wipe destroys every share in the stores of obs, each store once, and then
+// the enclave key.
+func wipe(obs []*Onionbox) error {
+	var errs []error
+	wiped := make(map[*onionstore.OnionStore]bool)
+	for _, ob := range obs {
+		if ob.Store == nil || wiped[ob.Store] {
+			continue
+		}
+		wiped[ob.Store] = true
+		if err := ob.Store.DestroyAll(); err != nil {
+			ob.logger().Error("Error destroying all buffers from Store", "err", err)
+			errs = append(errs, err)
+		}
+	}
+	if err := onionbuffer.DestroyEnclave(); err != nil {
+		logger := discardLogger
+		if len(obs) > 0 {
+			logger = obs[0].logger()
+		}
+		logger.Error("Error destroying enclave key", "err", err)
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// refuseWhileDraining answers uploads with 503 once shutdown has begun. It
+// reports whether the request was refused.
+func (ob *Onionbox) refuseWhileDraining(w http.ResponseWriter, r *http.Request) bool {
+	if !ob.draining.Load() {
+		return false
+	}
+	w.Header().Set("Connection", "close")
+	ob.httpError(w, r, ErrDraining)
+	return true
+}
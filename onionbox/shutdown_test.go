@@ -0,0 +1,90 @@
+package onionbox
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionstore"
+)
+
+func TestDrainRefusesUploads(t *testing.T) {
+	ob := Onionbox{Store: onionstore.NewStore()}
+	if err := ob.drain(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	http.HandlerFunc(ob.Router).ServeHTTP(w, newRequest(t, "GET", "/", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected response code %v, got %v", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestDrainWaitsForTransfers(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	ob := &Onionbox{Server: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("done"))
+	})}}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go ob.Server.Serve(ln)
+
+	body := make(chan string, 1)
+	go func() {
+		res, err := http.Get("http://" + ln.Addr().String())
+		if err != nil {
+			body <- err.Error()
+			return
+		}
+		defer res.Body.Close()
+		b, _ := ioutil.ReadAll(res.Body)
+		body <- string(b)
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() { drained <- ob.drain(context.Background()) }()
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case err := <-drained:
+		t.Fatalf("drain returned before the transfer finished: %v", err)
+	default:
+	}
+	close(release)
+	if b := <-body; b != "done" {
+		t.Errorf("expected the in-flight transfer to finish, got %q", b)
+	}
+	if err := <-drained; err != nil {
+		t.Error(err)
+	}
+}
+
+func TestDrainForced(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	ob := &Onionbox{Server: &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})}}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go ob.Server.Serve(ln)
+	go http.Get("http://" + ln.Addr().String())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := ob.drain(ctx); err != ErrShutdownForced {
+		t.Errorf("expected %v, got %v", ErrShutdownForced, err)
+	}
+}
@@ -0,0 +1,159 @@
+package onionbox
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Default HTTP timeouts. Transfers get the read or write timeout plus the
+// time their size takes at DefaultMinThroughput, so large shares can trickle
+// over slow Tor circuits while stalled clients are still cut off.
+const (
+	DefaultReadHeaderTimeout = 30 * time.Second
+	DefaultReadTimeout       = 3 * time.Minute
+	DefaultWriteTimeout      = 3 * time.Minute
+	DefaultIdleTimeout       = 3 * time.Minute
+	// DefaultMinThroughput is the slowest transfer rate allowed, in bytes
+	// per second.
+	DefaultMinThroughput = 4 << 10
+)
+
+const (
+	// stallTimeout is how long a transfer may go without moving a byte. It
+	// is shortened to the read or write timeout when that is shorter.
+	stallTimeout = 30 * time.Second
+	// maxTransferTime caps the time a transfer's size can add to its
+	// deadline, however large a size the client declares.
+	maxTransferTime = 24 * time.Hour
+	// pacedChunkSize is the most a paced writer writes under one deadline.
+	pacedChunkSize = 32 << 10
+)
+
+// throughputTime returns the time size bytes take at the minimum throughput,
+// saturating at maxTransferTime. Zero MinThroughput means no time at all.
+func (ob *Onionbox) throughputTime(size int64) time.Duration {
+	if ob.MinThroughput <= 0 || size <= 0 {
+		return 0
+	}
+	secs := size / ob.MinThroughput
+	if secs >= int64(maxTransferTime/time.Second) {
+		return maxTransferTime
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// transferDeadline returns when a transfer of size bytes starting now must
+// be done: base plus the time size takes at the minimum throughput. Zero
+// means no deadline.
+func (ob *Onionbox) transferDeadline(base time.Duration, size int64) time.Time {
+	if base <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(base + ob.throughputTime(size))
+}
+
+// pacer keeps a transfer above the minimum throughput while it moves. Before
+// each chunk it re-arms the connection deadline to the earliest of a stall
+// timeout from now, the time the bytes moved so far and that chunk are due
+// at MinThroughput after the base timeout, and the transfer's deadline. A
+// client stalling at 0 B/s is thus cut off after the stall timeout, and one
+// trickling slower than the minimum as soon as it falls behind.
+type pacer struct {
+	ob       *Onionbox
+	set      func(time.Time) error
+	base     time.Duration
+	start    time.Time
+	deadline time.Time
+	moved    int64
+}
+
+func (ob *Onionbox) newPacer(set func(time.Time) error, base time.Duration, size int64) *pacer {
+	return &pacer{ob: ob, set: set, base: base, start: time.Now(), deadline: ob.transferDeadline(base, size)}
+}
+
+// arm sets the deadline for moving the next chunk bytes.
+func (p *pacer) arm(chunk int) {
+	if p.base <= 0 {
+		return
+	}
+	stall := stallTimeout
+	if p.base < stall {
+		stall = p.base
+	}
+	// A chunk is always given the time it takes at the minimum throughput
+	if t := p.ob.throughputTime(int64(chunk)); t > stall {
+		stall = t
+	}
+	next := time.Now().Add(stall)
+	if p.ob.MinThroughput > 0 {
+		if due := p.start.Add(p.base + p.ob.throughputTime(p.moved+int64(chunk))); due.Before(next) {
+			next = due
+		}
+	}
+	if p.deadline.Before(next) {
+		next = p.deadline
+	}
+	p.ob.setDeadline(p.set, next)
+}
+
+// pacedBody paces the reading of a request body of size bytes. A negative
+// size, for bodies of unknown length, and sizes over the maximum upload size
+// count as the maximum upload size.
+func (ob *Onionbox) pacedBody(w http.ResponseWriter, body io.ReadCloser, size int64) io.ReadCloser {
+	if ob.MaxUploadSize > 0 && (size < 0 || size > ob.MaxUploadSize) {
+		size = ob.MaxUploadSize
+	}
+	return &pacedReader{ReadCloser: body, p: ob.newPacer(http.NewResponseController(w).SetReadDeadline, ob.ReadTimeout, size)}
+}
+
+type pacedReader struct {
+	io.ReadCloser
+	p *pacer
+}
+
+func (r *pacedReader) Read(b []byte) (int, error) {
+	r.p.arm(len(b))
+	n, err := r.ReadCloser.Read(b)
+	r.p.moved += int64(n)
+	return n, err
+}
+
+// pacedWriter paces writing a response of size bytes to w, once its headers
+// are set.
+func (ob *Onionbox) pacedWriter(w http.ResponseWriter, size int64) io.Writer {
+	return &pacedResponse{w: w, p: ob.newPacer(http.NewResponseController(w).SetWriteDeadline, ob.WriteTimeout, size)}
+}
+
+type pacedResponse struct {
+	w io.Writer
+	p *pacer
+}
+
+// Write writes b in chunks, each under a deadline of its own.
+func (pw *pacedResponse) Write(b []byte) (int, error) {
+	var written int
+	for len(b) > 0 {
+		chunk := b
+		if len(chunk) > pacedChunkSize {
+			chunk = chunk[:pacedChunkSize]
+		}
+		pw.p.arm(len(chunk))
+		n, err := pw.w.Write(chunk)
+		written += n
+		pw.p.moved += int64(n)
+		if err != nil {
+			return written, err
+		}
+		b = b[n:]
+	}
+	return written, nil
+}
+
+func (ob *Onionbox) setDeadline(set func(time.Time) error, deadline time.Time) {
+	// Writers such as test recorders have no connection to set deadlines on
+	if err := set(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		ob.logger().Warn("Error setting connection deadline", "err", err)
+	}
+}
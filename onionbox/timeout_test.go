@@ -0,0 +1,134 @@
+package onionbox
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTransferDeadline(t *testing.T) {
+	ob := &Onionbox{MinThroughput: 1 << 10}
+	start := time.Now()
+	deadline := ob.transferDeadline(time.Minute, 10<<20)
+	// 10 MiB at 1 KiB/s takes 10240 seconds on top of the base timeout
+	if d := deadline.Sub(start); d < time.Minute+10240*time.Second || d > time.Minute+10241*time.Second {
+		t.Errorf("unexpected deadline %s from now", d)
+	}
+	if !ob.transferDeadline(0, 10<<20).IsZero() {
+		t.Error("expected a disabled timeout to set no deadline")
+	}
+
+	ob.MinThroughput = 0
+	if d := ob.transferDeadline(time.Minute, 10<<20).Sub(start); d > time.Minute+time.Second {
+		t.Errorf("expected only the base timeout without a minimum throughput, got %s", d)
+	}
+}
+
+func TestTransferDeadlineOverflow(t *testing.T) {
+	ob := &Onionbox{MinThroughput: 1}
+	start := time.Now()
+	// A declared size too large for a Duration saturates instead of wrapping
+	d := ob.transferDeadline(time.Minute, math.MaxInt64).Sub(start)
+	if d < time.Minute+maxTransferTime || d > time.Minute+maxTransferTime+time.Second {
+		t.Errorf("unexpected deadline %s from now", d)
+	}
+}
+
+func TestPacer(t *testing.T) {
+	ob := &Onionbox{MinThroughput: 1 << 10}
+	var deadline time.Time
+	p := ob.newPacer(func(d time.Time) error { deadline = d; return nil }, time.Minute, 10<<20)
+
+	// Moving bytes, a transfer is only given the stall timeout per chunk
+	p.arm(1 << 10)
+	if d := time.Until(deadline); d > stallTimeout || d < stallTimeout-time.Second {
+		t.Errorf("expected the stall timeout, got %s", d)
+	}
+	// unless the chunk takes longer at the minimum throughput
+	p.arm(100 << 10)
+	if d := time.Until(deadline); d > 100*time.Second || d < 99*time.Second {
+		t.Errorf("expected the chunk's time at the minimum throughput, got %s", d)
+	}
+	// A transfer behind the minimum throughput is cut off
+	p.start = time.Now().Add(-2 * time.Minute)
+	p.moved = 10 << 10
+	p.arm(1 << 10)
+	if !deadline.Before(time.Now()) {
+		t.Errorf("expected a transfer behind the minimum throughput to be cut off, got %s", time.Until(deadline))
+	}
+}
+
+func TestIntegrationStalledUpload(t *testing.T) {
+	s := newTestServer(t, &Onionbox{ReadTimeout: 200 * time.Millisecond, WriteTimeout: time.Second, MinThroughput: DefaultMinThroughput})
+	conn, err := net.Dial("tcp", strings.TrimPrefix(s.base, "http://"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	// Declaring a huge body then stalling must not hold the connection
+	start := time.Now()
+	fmt.Fprintf(conn, "POST / HTTP/1.1\r\nHost: onionbox\r\nContent-Type: multipart/form-data; boundary=x\r\nContent-Length: 10000000000\r\n\r\n--x\r\n")
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := io.Copy(io.Discard, conn); err != nil {
+		t.Fatalf("expected the server to close the connection, got %v", err)
+	}
+	if d := time.Since(start); d > 2*time.Second {
+		t.Errorf("expected a stalled upload to be cut off, took %s", d)
+	}
+}
+
+// slowReader returns a few bytes at a time, pausing between them.
+type slowReader struct {
+	r     io.Reader
+	pause time.Duration
+}
+
+func (r *slowReader) Read(p []byte) (int, error) {
+	time.Sleep(r.pause)
+	if len(p) > 64 {
+		p = p[:64]
+	}
+	return r.r.Read(p)
+}
+
+func TestIntegrationSlowUpload(t *testing.T) {
+	s := newTestServer(t, &Onionbox{ReadTimeout: 300 * time.Millisecond, WriteTimeout: 300 * time.Millisecond, MinThroughput: 1})
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField(formCSRF, s.csrf("/"))
+	mw.WriteField("archive", "none")
+	fw, err := mw.CreateFormFile("files", "slow.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	io.WriteString(fw, strings.Repeat("slow but alive ", 20))
+	mw.Close()
+
+	// Trickling well past the read timeout, the upload keeps moving
+	req, err := http.NewRequest("POST", s.base+"/", &slowReader{r: bytes.NewReader(body.Bytes()), pause: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.ContentLength = int64(body.Len())
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected a slow but moving upload to succeed, got %v", resp.StatusCode)
+	}
+	if d := time.Since(start); d < 300*time.Millisecond {
+		t.Errorf("expected the upload to outlast the read timeout, took %s", d)
+	}
+}
@@ -0,0 +1,14 @@
+//go:build linux
+
+package onionbox
+
+import "golang.org/x/sys/unix"
+
+// onTmpfs reports whether path is on a file system kept in memory.
+func onTmpfs(path string) bool {
+	var st unix.Statfs_t
+	if err := unix.Statfs(path, &st); err != nil {
+		return false
+	}
+	return st.Type == unix.TMPFS_MAGIC || st.Type == unix.RAMFS_MAGIC
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package onionbox
+
+// onTmpfs reports false, since only Linux is known to keep a tmpfs at hand.
+func onTmpfs(path string) bool {
+	return false
+}
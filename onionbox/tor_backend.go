@@ -0,0 +1,71 @@
+package onionbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+
+	"github.com/cretz/bine/control"
+	"github.com/cretz/bine/tor"
+)
+
+// ErrNoEmbeddedTor is returned when starting Tor in a build without the
+// embedded Tor and with no external Tor configured.
+var ErrNoEmbeddedTor = errors.New("built without embedded Tor (nolibtor), set a Tor control address")
+
+// TorBackend provides the Tor instance onion services are published
+// through.
+type TorBackend interface {
+	// Start returns a Tor instance with an authenticated control
+	// connection. conf holds onionbox's settings for Tor; backends that do
+	// not start Tor themselves use only its DebugWriter.
+	Start(ctx context.Context, conf *tor.StartConf) (*tor.Tor, error)
+}
+
+// ExternalTor is a TorBackend using an already running Tor, such as the
+// system's, through its control port. Onion services are created with
+// ADD_ONION and removed again when onionbox closes the connection.
+type ExternalTor struct {
+	// Addr is the control port's host:port, or a control socket's path
+	// prefixed with unix:.
+	Addr string
+	// Password authenticates with HashedControlPassword. Without it, cookie
+	// authentication is used if Tor offers it.
+	Password string
+}
+
+// Start connects and authenticates to the control port.
+func (e *ExternalTor) Start(ctx context.Context, conf *tor.StartConf) (*tor.Tor, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	network, addr := "tcp", e.Addr
+	if strings.HasPrefix(addr, "unix:") {
+		network, addr = "unix", strings.TrimPrefix(addr, "unix:")
+	}
+	var d net.Dialer
+	c, err := d.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to Tor control port: %w", err)
+	}
+	conn := control.NewConn(textproto.NewConn(c))
+	conn.DebugWriter = conf.DebugWriter
+	if err := conn.Authenticate(e.Password); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("authenticating to Tor control port: %w", err)
+	}
+	// The Tor process is not ours, so closing only closes the connection
+	return &tor.Tor{Control: conn, DebugWriter: conf.DebugWriter}, nil
+}
+
+// torBackend returns the configured TorBackend, defaulting to the embedded
+// Tor.
+func (ob *Onionbox) torBackend() TorBackend {
+	if ob.TorBackend != nil {
+		return ob.TorBackend
+	}
+	return defaultTorBackend()
+}
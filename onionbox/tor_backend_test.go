@@ -0,0 +1,89 @@
+package onionbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/cretz/bine/tor"
+)
+
+// fakeControlPort answers a Tor control port's authentication handshake,
+// accepting only password.
+func fakeControlPort(t *testing.T, password string) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			c, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(c net.Conn) {
+				defer c.Close()
+				r := bufio.NewReader(c)
+				for {
+					line, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					switch cmd := strings.TrimSpace(line); {
+					case strings.HasPrefix(cmd, "PROTOCOLINFO"):
+						c.Write([]byte("250-PROTOCOLINFO 1\r\n250-AUTH METHODS=HASHEDPASSWORD\r\n250-VERSION Tor=\"0.4.8.9\"\r\n250 OK\r\n"))
+					case cmd == "AUTHENTICATE "+hex.EncodeToString([]byte(password)):
+						c.Write([]byte("250 OK\r\n"))
+					case strings.HasPrefix(cmd, "AUTHENTICATE"):
+						c.Write([]byte("515 Authentication failed: Password did not match\r\n"))
+					default:
+						c.Write([]byte("510 Unrecognized command\r\n"))
+					}
+				}
+			}(c)
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestExternalTor(t *testing.T) {
+	addr := fakeControlPort(t, "hunter2")
+	backend := &ExternalTor{Addr: addr, Password: "hunter2"}
+	tr, err := backend.Start(context.Background(), &tor.StartConf{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tr.Control.Authenticated || tr.Process != nil || tr.StopProcessOnClose {
+		t.Errorf("expected an authenticated connection to a Tor we do not own, got %+v", tr)
+	}
+	if err := tr.Close(); err != nil {
+		t.Error(err)
+	}
+
+	backend.Password = "wrong"
+	if _, err := backend.Start(context.Background(), &tor.StartConf{}); err == nil || !strings.Contains(err.Error(), "authenticating") {
+		t.Errorf("expected an authentication error, got %v", err)
+	}
+
+	backend.Addr = "unix:" + t.TempDir() + "/missing.sock"
+	var opErr *net.OpError
+	if _, err := backend.Start(context.Background(), &tor.StartConf{}); !errors.As(err, &opErr) {
+		t.Errorf("expected a connection error, got %v", err)
+	}
+}
+
+func TestTorBackendConfigured(t *testing.T) {
+	backend := &ExternalTor{Addr: "127.0.0.1:9051"}
+	ob := Onionbox{TorBackend: backend}
+	if ob.torBackend() != backend {
+		t.Error("expected the configured backend to be used")
+	}
+	if (&Onionbox{}).torBackend() == nil {
+		t.Error("expected a default backend")
+	}
+}
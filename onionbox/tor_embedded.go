@@ -0,0 +1,33 @@
+//go:build !nolibtor
+
+package onionbox
+
+import (
+	"context"
+
+	"github.com/cretz/bine/tor"
+	"github.com/ipsn/go-libtor"
+)
+
+// EmbeddedTor is the default TorBackend, running Tor inside the onionbox
+// process through go-libtor. Build with the nolibtor tag to leave it out,
+// which removes the need for CGO.
+type EmbeddedTor struct{}
+
+// Start starts the embedded Tor.
+func (EmbeddedTor) Start(ctx context.Context, conf *tor.StartConf) (*tor.Tor, error) {
+	c := *conf
+	c.ProcessCreator = libtor.Creator
+	c.UseEmbeddedControlConn = true // Since we are using embedded tor via go-libtor
+	return tor.Start(ctx, &c)
+}
+
+// EmbeddedTorVersion returns the version of the embedded Tor, or an empty
+// string if onionbox was built without it.
+func EmbeddedTorVersion() string {
+	return libtor.ProviderVersion()
+}
+
+func defaultTorBackend() TorBackend {
+	return EmbeddedTor{}
+}
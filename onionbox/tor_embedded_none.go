@@ -0,0 +1,26 @@
+//go:build nolibtor
+
+package onionbox
+
+import (
+	"context"
+
+	"github.com/cretz/bine/tor"
+)
+
+// noEmbeddedTor stands in for the embedded Tor in builds without it.
+type noEmbeddedTor struct{}
+
+func (noEmbeddedTor) Start(context.Context, *tor.StartConf) (*tor.Tor, error) {
+	return nil, ErrNoEmbeddedTor
+}
+
+// EmbeddedTorVersion returns the version of the embedded Tor, or an empty
+// string if onionbox was built without it.
+func EmbeddedTorVersion() string {
+	return ""
+}
+
+func defaultTorBackend() TorBackend {
+	return noEmbeddedTor{}
+}
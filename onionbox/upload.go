@@ -1,22 +1,21 @@
 package onionbox
 
 import (
-	"archive/zip"
 	"bytes"
-	"crypto/subtle"
 	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"html/template"
 	"image"
 	"image/png"
-	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
-	"sync"
-	"syscall"
+	"time"
 
 	"github.com/ciehanski/onionbox/onionbuffer"
+	"github.com/ciehanski/onionbox/onionstore"
 	"github.com/ciehanski/onionbox/templates"
 
 	"github.com/Pallinder/go-randomdata"
@@ -24,13 +23,16 @@ import (
 )
 
 func (ob *Onionbox) upload(w http.ResponseWriter, r *http.Request) {
+	if ob.refuseWhileDraining(w, r) {
+		return
+	}
 	switch r.Method {
 	case http.MethodGet:
 		ob.uploadGet(w, r)
 	case http.MethodPost:
 		ob.uploadPost(w, r)
 	default:
-		http.Error(w, "Invalid HTTP Method.", http.StatusMethodNotAllowed)
+		ob.httpError(w, r, ErrMethodNotAllowed)
 		return
 	}
 }
@@ -38,8 +40,8 @@ func (ob *Onionbox) upload(w http.ResponseWriter, r *http.Request) {
 func (ob *Onionbox) uploadGet(w http.ResponseWriter, r *http.Request) {
 	csrf, err := createCSRF() // Create CSRF to inject into template
 	if err != nil {
-		ob.Logf("Error creating CSRF token: %v", err)
-		http.Error(w, "Error displaying web page, please try refreshing.", http.StatusInternalServerError)
+		ob.logger().Error("Error creating CSRF token", "err", err)
+		ob.httpError(w, r, err)
 		return
 	}
 
@@ -55,170 +57,241 @@ func (ob *Onionbox) uploadGet(w http.ResponseWriter, r *http.Request) {
 
 	t, err := template.New("upload").Parse(templates.UploadHTML) // Parse template
 	if err != nil {
-		ob.Logf("Error parsing template: %v", err)
-		http.Error(w, "Error displaying web page, please try refreshing.", http.StatusInternalServerError)
+		ob.logger().Error("Error parsing template", "err", err)
+		ob.httpError(w, r, err)
 		return
 	}
 
-	if err := t.Execute(w, csrf); err != nil { // Execute template
-		ob.Logf("Error executing template: %v", err)
-		http.Error(w, "Error displaying web page, please try refreshing.", http.StatusInternalServerError)
+	data := map[string]interface{}{"CSRF": csrf, "Banner": ob.Banner}
+	if err := t.Execute(w, data); err != nil { // Execute template
+		ob.logger().Error("Error executing template", "err", err)
+		ob.httpError(w, r, err)
 		return
 	}
 }
 
 func (ob *Onionbox) uploadPost(w http.ResponseWriter, r *http.Request) {
-	// Check CSRF
-	csrfForm := r.FormValue(formCSRF)
-	csrfCookie, err := r.Cookie(cookieCSRF)
-	if err != nil {
-		ob.Logf("Error getting CSRF cookie: %v", err)
-		http.Error(w, "Error getting CSRF.", http.StatusInternalServerError)
-		return
+	if ob.MaxUploadSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, ob.MaxUploadSize)
 	}
-	if subtle.ConstantTimeCompare([]byte(csrfForm), []byte(csrfCookie.Value)) == 0 {
-		ob.Logf("Form CSRF and Cookie CSRF values do not match")
-		http.Error(w, "Invalid CSRF value.", http.StatusUnauthorized)
+	r.Body = ob.pacedBody(w, r.Body, r.ContentLength)
+	err := r.ParseMultipartForm(32 << 20) // Parse file(s) from form
+	// The write timeout runs from the end of the upload, however long it took
+	ob.setDeadline(http.NewResponseController(w).SetWriteDeadline, ob.transferDeadline(ob.WriteTimeout, 0))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ob.logger().Warn("Upload exceeds the maximum upload size", "limit", tooLarge.Limit)
+			ob.httpError(w, r, err)
+			return
+		}
+		ob.logger().Warn("Error parsing files from form", "err", err)
+		ob.httpError(w, r, badRequest("Error parsing files.", err))
 		return
 	}
 
-	if err := r.ParseMultipartForm(32 << 20); err != nil { // Parse file(s) from form
-		ob.Logf("Error parsing files from form: %v", err)
-		http.Error(w, "Error parsing files.", http.StatusInternalServerError)
+	// Check CSRF
+	if err := checkCSRF(r); err != nil {
+		ob.logger().Warn("Error checking CSRF", "err", err)
+		ob.httpError(w, r, err)
 		return
 	}
-	files := r.MultipartForm.File["files"]
-
-	uploadQueue := make(chan *multipart.FileHeader, len(files)) // A channel that we can queue upload requests on
 
-	var fileSizes int64
-	for _, fileHeader := range files { // Loop through files attached in form and offload to uploadQueue channel
-		fileSizes += fileHeader.Size
-		uploadQueue <- fileHeader
+	var files []onionbuffer.SourceFile
+	for _, fileHeader := range r.MultipartForm.File["files"] {
+		files = append(files, onionbuffer.UploadedFile(fileHeader))
 	}
 
-	// mmapBytes, err := onionbuffer.Allocate(int(fileSizes))
-	// if err != nil {
-	// 	ob.Logf("Error allocating bytes for zip: %v", err)
-	// 	http.Error(w, "Error allocating bytes.", http.StatusInternalServerError)
-	// 	return
-	// }
-	// zBuffer := bytes.NewBuffer(mmapBytes) // Create buffer for session's in-memory zip file
-	zBuffer := new(bytes.Buffer)
-	if err := syscall.Mlock(zBuffer.Bytes()); err != nil { // Lock memory allotted to zBuffer from being used in SWAP
-		ob.Logf("Error mlocking allotted memory for zBuffer: %v", err)
+	format, err := onionbuffer.ParseArchiveFormat(r.FormValue("archive"))
+	if err != nil {
+		ob.logger().Warn("Error parsing archive format", "err", err)
+		ob.httpError(w, r, err)
+		return
+	}
+	if format == onionbuffer.ArchiveNone && len(files) != 1 {
+		ob.httpError(w, r, onionbuffer.ErrSingleFileOnly)
+		return
 	}
-	zWriter := zip.NewWriter(zBuffer) // Create new zip file
-
-	wg := new(sync.WaitGroup) // Wait group for sync
-	wg.Add(len(files))
 
-	go func() { // Write all files in queue to new zip file
-		if err := onionbuffer.WriteFilesToZip(zWriter, uploadQueue, wg); err != nil {
-			ob.Logf("Error writing files in queue to memory: %v", err)
-			http.Error(w, "Error writing your files to memory.", http.StatusInternalServerError)
+	var pass string
+	if r.FormValue("password_enabled") == "on" { // If password option was enabled
+		pass = r.FormValue("password")
+	}
+	oBuffer, err := ob.newShare(files, format, pass)
+	if errors.Is(err, onionbuffer.ErrReservedName) {
+		ob.logger().Warn("Upload refused, reserved file name", "err", err)
+		ob.httpError(w, r, err)
+		return
+	} else if err != nil {
+		ob.logger().Error("Error writing files to memory", "err", err)
+		ob.httpError(w, r, err)
+		return
+	}
+	// Wipe the share if it is refused, or stored but failed to lock
+	stored := false
+	defer func() {
+		if stored {
 			return
 		}
+		err := ob.Store.Destroy(oBuffer)
+		if err == nil {
+			err = oBuffer.Destroy()
+		}
+		if err != nil {
+			ob.logger().Warn("Error destroying refused share", "err", err)
+		}
 	}()
 
-	wg.Wait() // Wait for zip to be finished
+	if r.FormValue("limit_downloads") == "on" { // If limit downloads was enabled
+		form := r.FormValue("download_limit")
+		limit, err := strconv.Atoi(form)
+		if err != nil || limit < 0 {
+			ob.logger().Warn("Error parsing download limit", "download_limit", form, "err", err)
+			ob.httpError(w, r, badRequest("Invalid download limit.", err))
+			return
+		}
+		oBuffer.DownloadLimit = int64(limit)
+	} else {
+		oBuffer.DownloadLimit = ob.DefaultDownloadLimit
+	}
 
-	if err := zWriter.Close(); err != nil { // Close zipwriter
-		ob.Logf("Error closing zip writer: %v", err)
+	if !ob.setLifetime(w, r, oBuffer) {
+		return
 	}
 
-	// Create OnionBuffer object
-	oBuffer := onionbuffer.OnionBuffer{
-		Name:  strings.ToLower(randomdata.SillyName()),
-		Bytes: make([]byte, len(zBuffer.Bytes())),
+	if err := ob.Store.Add(oBuffer); errors.Is(err, onionstore.ErrQuotaExceeded) {
+		ob.logger().Warn("Upload refused, store memory quota exceeded", "size", len(oBuffer.Bytes))
+		ob.httpError(w, r, err)
+		return
+	} else if err != nil { // Add OnionBuffer to Store
+		ob.logger().Error("Error adding file to store", "err", err)
+		ob.httpError(w, r, err)
+		return
 	}
+	stored = true
 
-	if r.FormValue("password_enabled") == "on" { // If password option was enabled
-		var err error
-		pass := r.FormValue("password")
-		oBuffer.Bytes, err = onionbuffer.Encrypt(zBuffer.Bytes(), pass)
-		if err != nil {
-			ob.Logf("Error encrypting buffer: %v", err)
-			http.Error(w, "Error encrypting buffer.", http.StatusInternalServerError)
-			return
+	shareURL := fmt.Sprintf("http://%s.onion/%s", ob.shareOnion(), oBuffer.Name)
+	if ob.OnShare != nil {
+		ob.OnShare(shareURL, oBuffer)
+	}
+	if wantsJSON(r) { // Scripted clients such as onionbox put
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if err := json.NewEncoder(w).Encode(map[string]string{"url": shareURL, "sha256": oBuffer.Checksum}); err != nil {
+			ob.logger().Warn("Error writing to client", "err", err)
 		}
+		return
+	}
+	if err := writeUploadComplete(w, shareURL, oBuffer.Checksum, ob.SigningKey != nil); err != nil {
+		ob.logger().Warn("Error writing to client", "err", err)
+		ob.httpError(w, r, err)
+		return
+	}
+}
 
-		// Empty zBuffer after copied to onionbuffer
-		if err := syscall.Munlock(zBuffer.Bytes()); err != nil {
-			ob.Logf("Error munlocking allotted memory for zBuffer: %v", err)
+// newShare archives files into a new, uniquely named OnionBuffer, encrypted
+// with pass unless it is empty. It is not yet added to the store.
+func (ob *Onionbox) newShare(files []onionbuffer.SourceFile, format onionbuffer.ArchiveFormat, pass string) (*onionbuffer.OnionBuffer, error) {
+	oBuffer, err := onionbuffer.NewArchive(format, files)
+	if err != nil {
+		return nil, err
+	}
+	oBuffer.Name = NewShareName()
+	if pass != "" {
+		if err := oBuffer.SetPassword(pass); err != nil {
+			return nil, err
 		}
-		zBuffer.Reset()
+	}
+	return oBuffer, nil
+}
 
-		if err := oBuffer.Mlock(); err != nil {
-			ob.Logf("Error mlocking allotted memory for oBuffer: %v", err)
-		}
+// NewShareName returns a random, human friendly name for a new share.
+func NewShareName() string {
+	return strings.ToLower(randomdata.SillyName())
+}
+
+// wantsJSON reports whether the client asked for a JSON response.
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
 
-		oBuffer.Encrypted = true
+// formTimeLayout is the layout of the datetime-local inputs of the upload
+// form. Times are entered in UTC.
+const formTimeLayout = "2006-01-02T15:04"
 
-		oBuffer.Checksum, err = oBuffer.GetChecksum()
+// setLifetime applies the lifetime policies chosen in the upload form to
+// oBuffer. If any of them is invalid the client is told so and false is
+// returned.
+func (ob *Onionbox) setLifetime(w http.ResponseWriter, r *http.Request, oBuffer *onionbuffer.OnionBuffer) bool {
+	if form := r.FormValue("not_before"); form != "" { // If the share is embargoed
+		notBefore, err := time.Parse(formTimeLayout, form)
 		if err != nil {
-			ob.Logf("Error getting buffer's checksum: %v", err)
-			http.Error(w, "Error getting checksum.", http.StatusInternalServerError)
-			return
+			ob.logger().Warn("Error parsing not before time", "err", err)
+			ob.httpError(w, r, badRequest("Invalid availability date.", err))
+			return false
 		}
-	} else { // If password option was NOT enabled
-		oBuffer.Bytes = zBuffer.Bytes()
-
-		// Empty zBuffer after copied to onionbuffer
-		if err := syscall.Munlock(zBuffer.Bytes()); err != nil {
-			ob.Logf("Error munlocking allotted memory for zBuffer: %v", err)
+		if err := oBuffer.SetNotBefore(notBefore); err != nil {
+			ob.logger().Warn("Error setting not before time", "err", err)
+			ob.httpError(w, r, badRequest("Invalid availability date.", err))
+			return false
 		}
-		zBuffer.Reset()
+	}
 
-		if err := oBuffer.Mlock(); err != nil {
-			ob.Logf("Error mlocking allotted memory for oBuffer: %v", err)
+	if r.FormValue("expire") == "on" { // if expiration was enabled
+		expiration := fmt.Sprintf("%sm", r.FormValue("expiration_time"))
+		if err := oBuffer.SetExpiration(expiration); err != nil {
+			ob.logger().Warn("Error parsing expiration time", "err", err)
+			ob.httpError(w, r, badRequest("Invalid expiration time.", err))
+			return false
 		}
+	}
 
-		oBuffer.Checksum, err = oBuffer.GetChecksum() // Get checksum
+	if form := r.FormValue("expires_at"); form != "" { // If an absolute deadline was set
+		deadline, err := time.Parse(formTimeLayout, form)
 		if err != nil {
-			ob.Logf("Error getting checksum: %v", err)
-			http.Error(w, "Error getting checksum.", http.StatusInternalServerError)
-			return
+			ob.logger().Warn("Error parsing expiration date", "err", err)
+			ob.httpError(w, r, badRequest("Invalid expiration date.", err))
+			return false
+		}
+		if err := oBuffer.SetDeadline(deadline); err != nil {
+			ob.logger().Warn("Error setting expiration date", "err", err)
+			ob.httpError(w, r, badRequest("Invalid expiration date.", err))
+			return false
 		}
 	}
 
-	if r.FormValue("limit_downloads") == "on" { // If limit downloads was enabled
-		form := r.FormValue("download_limit")
-		limit, err := strconv.Atoi(form)
+	if r.FormValue("idle_expire") == "on" { // If idle expiration was enabled
+		idle, err := time.ParseDuration(fmt.Sprintf("%sm", r.FormValue("idle_timeout")))
+		if err == nil {
+			err = oBuffer.SetIdleTimeout(idle)
+		}
 		if err != nil {
-			ob.Logf("Error converting duration string into time.Duration: %v", err)
-			http.Error(w, "Error getting expiration time.", http.StatusInternalServerError)
-			return
+			ob.logger().Warn("Error setting idle timeout", "err", err)
+			ob.httpError(w, r, badRequest("Invalid idle timeout.", err))
+			return false
 		}
-		oBuffer.DownloadLimit = int64(limit)
 	}
 
-	if r.FormValue("expire") == "on" { // if expiration was enabled
-		expiration := fmt.Sprintf("%sm", r.FormValue("expiration_time"))
-		if err := oBuffer.SetExpiration(expiration); err != nil {
-			ob.Logf("Error parsing expiration time: %v", err)
-			http.Error(w, "Error parsing expiration time.", http.StatusInternalServerError)
-			return
+	if !oBuffer.Expire && ob.DefaultExpiry > 0 { // Fall back on the operator's default expiry
+		start := time.Now()
+		if oBuffer.NotBefore.After(start) {
+			start = oBuffer.NotBefore
+		}
+		if err := oBuffer.SetDeadline(start.Add(ob.DefaultExpiry)); err != nil {
+			ob.logger().Error("Error setting default expiration", "err", err)
+			ob.httpError(w, r, err)
+			return false
 		}
 	}
 
-	if err := ob.Store.Add(&oBuffer); err != nil { // Add OnionBuffer to Store
-		ob.Logf("Error adding file to store: %v", err)
-		http.Error(w, "Error adding file to store.", http.StatusInternalServerError)
-		return
-	}
-
-	if err := writeUploadComplete(w, fmt.Sprintf("http://%s.onion/%s", ob.OnionURL, oBuffer.Name)); err != nil {
-		ob.Logf("Error writing to client: %v", err)
-		http.Error(w, "Error writing to client.", http.StatusInternalServerError)
-		return
-	}
+	oBuffer.BurnAfterRead = r.FormValue("burn") == "on"
+	return true
 }
 
 // writeUploadComplete writes the UploadCompleteHTML contents to the browser
-// with the onionbuffer download link & generated QR code image.
+// with the onionbuffer download link, its SHA-256 checksum, manifest links
+// & generated QR code image.
 // ref: https://www.sanarias.com/blog/1214PlayingwithimagesinHTTPresponseingolang
-func writeUploadComplete(w http.ResponseWriter, onionAddr string) error {
+func writeUploadComplete(w http.ResponseWriter, onionAddr, checksum string, signed bool) error {
 	// Generate QR code for download URL
 	qrCode, err := qrcode.Encode(onionAddr, qrcode.Medium, 256)
 	if err != nil {
@@ -240,7 +313,7 @@ func writeUploadComplete(w http.ResponseWriter, onionAddr string) error {
 	if tmpl, err := template.New("upload_complete").Parse(templates.UploadCompleteHTML); err != nil {
 		return err
 	} else {
-		data := map[string]interface{}{"OnionAddr": onionAddr, "QR": str}
+		data := map[string]interface{}{"OnionAddr": onionAddr, "QR": str, "Checksum": checksum, "Signed": signed}
 		if err = tmpl.Execute(w, data); err != nil {
 			return err
 		}
@@ -0,0 +1,61 @@
+package onionbox
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ciehanski/onionbox/onionstore"
+)
+
+func FuzzUploadForm(f *testing.F) {
+	f.Add("hello.txt", "none", "", "", "", "", "")
+	f.Add("hello.txt", "zip", "3", "60", "", "", "")
+	f.Add("hello.txt", "tar.gz", "-1", "-5", "2030-01-02T15:04", "2020-01-02T15:04", "10")
+	f.Add("SHA256SUMS", "tar.zst", "x", "1e9", "x", "x", "x")
+	f.Add("", "rar", "99999999999999999999", "", "", "", "-1")
+	f.Fuzz(func(t *testing.T, filename, archive, limit, expiration, notBefore, expiresAt, idle string) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fields := map[string]string{
+			formCSRF:          "fuzzing",
+			"archive":         archive,
+			"limit_downloads": "on",
+			"download_limit":  limit,
+			"expire":          "on",
+			"expiration_time": expiration,
+			"not_before":      notBefore,
+			"expires_at":      expiresAt,
+			"idle_expire":     "on",
+			"idle_timeout":    idle,
+		}
+		for k, v := range fields {
+			mw.WriteField(k, v)
+		}
+		fw, err := mw.CreateFormFile("files", filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte("hello"))
+		mw.Close()
+
+		r := httptest.NewRequest("POST", "/", &body)
+		r.Header.Set("Content-Type", mw.FormDataContentType())
+		r.Header.Set("Accept", "application/json")
+		r.AddCookie(&http.Cookie{Name: cookieCSRF, Value: "fuzzing"})
+		ob := &Onionbox{Store: onionstore.NewStore()}
+		defer ob.Store.DestroyAll()
+		w := httptest.NewRecorder()
+		ob.Router(w, r)
+
+		// Whatever the uploader sends is at worst a bad request
+		if w.Code >= http.StatusInternalServerError {
+			t.Errorf("form %q gave %v: %s", fields, w.Code, w.Body)
+		}
+		if stored := len(ob.Store.BufferFiles); (w.Code == http.StatusOK) != (stored == 1) {
+			t.Errorf("form %q gave %v with %d shares stored", fields, w.Code, stored)
+		}
+	})
+}
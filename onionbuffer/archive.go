@@ -0,0 +1,456 @@
+package onionbuffer
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ArchiveFormat is the container a share's files are written into.
+type ArchiveFormat string
+
+const (
+	ArchiveZip    ArchiveFormat = "zip"
+	ArchiveTarGz  ArchiveFormat = "tar.gz"
+	ArchiveTarZst ArchiveFormat = "tar.zst"
+	// ArchiveNone stores a single file as is, keeping its name and MIME type.
+	ArchiveNone ArchiveFormat = "none"
+)
+
+var (
+	ErrUnknownArchiveFormat = errors.New("unknown archive format")
+	ErrSingleFileOnly       = errors.New("only a single file can be shared without an archive")
+	ErrFileNotFound         = errors.New("file not found in archive")
+	// ErrReservedName is returned when archiving a file named like the
+	// ChecksumsFile added alongside it, which recipients would mistake for
+	// the real one.
+	ErrReservedName = errors.New("file name is reserved: " + ChecksumsFile)
+)
+
+// ParseArchiveFormat parses a format chosen by the uploader. An empty value
+// defaults to zip, the most universally supported format.
+func ParseArchiveFormat(s string) (ArchiveFormat, error) {
+	switch f := ArchiveFormat(s); f {
+	case "":
+		return ArchiveZip, nil
+	case ArchiveZip, ArchiveTarGz, ArchiveTarZst, ArchiveNone:
+		return f, nil
+	default:
+		return "", ErrUnknownArchiveFormat
+	}
+}
+
+// Extension returns the file extension of the format, without a leading dot.
+func (f ArchiveFormat) Extension() string {
+	if f == ArchiveNone {
+		return ""
+	}
+	return string(f)
+}
+
+// ContentType returns the MIME type of the format.
+func (f ArchiveFormat) ContentType() string {
+	switch f {
+	case ArchiveTarGz:
+		return "application/gzip"
+	case ArchiveTarZst:
+		return "application/zstd"
+	case ArchiveZip:
+		return "application/zip"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// ArchiveWriter writes uploaded files into a single share archive.
+type ArchiveWriter interface {
+	// Create adds a new file of the given size to the archive. Its contents
+	// must be fully written before the next call to Create.
+	Create(name string, size int64) (io.Writer, error)
+	// Flush writes any buffered data to the underlying writer.
+	Flush() error
+	// Close finishes the archive. It does not close the underlying writer.
+	Close() error
+}
+
+// NewArchiveWriter returns an ArchiveWriter of the given format writing to w.
+func NewArchiveWriter(format ArchiveFormat, w io.Writer) (ArchiveWriter, error) {
+	switch format {
+	case ArchiveZip:
+		return &zipWriter{zip.NewWriter(w)}, nil
+	case ArchiveTarGz:
+		gw := gzip.NewWriter(w)
+		return &tarWriter{tar.NewWriter(gw), gw}, nil
+	case ArchiveTarZst:
+		zw, err := zstd.NewWriter(w)
+		if err != nil {
+			return nil, err
+		}
+		return &tarWriter{tar.NewWriter(zw), zw}, nil
+	case ArchiveNone:
+		return &passthroughWriter{w: w}, nil
+	default:
+		return nil, ErrUnknownArchiveFormat
+	}
+}
+
+type zipWriter struct {
+	*zip.Writer
+}
+
+func (z *zipWriter) Create(name string, _ int64) (io.Writer, error) {
+	return z.Writer.Create(name)
+}
+
+// compressor is the compression layer beneath a tar stream.
+type compressor interface {
+	io.Writer
+	Flush() error
+	Close() error
+}
+
+type tarWriter struct {
+	tw *tar.Writer
+	cw compressor
+}
+
+func (t *tarWriter) Create(name string, size int64) (io.Writer, error) {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := t.tw.WriteHeader(hdr); err != nil {
+		return nil, err
+	}
+	return t.tw, nil
+}
+
+func (t *tarWriter) Flush() error {
+	if err := t.tw.Flush(); err != nil {
+		return err
+	}
+	return t.cw.Flush()
+}
+
+func (t *tarWriter) Close() error {
+	if err := t.tw.Close(); err != nil {
+		return err
+	}
+	return t.cw.Close()
+}
+
+// passthroughWriter writes a single file's bytes unchanged.
+type passthroughWriter struct {
+	w       io.Writer
+	created bool
+}
+
+func (p *passthroughWriter) Create(string, int64) (io.Writer, error) {
+	if p.created {
+		return nil, ErrSingleFileOnly
+	}
+	p.created = true
+	return p.w, nil
+}
+
+func (p *passthroughWriter) Flush() error { return nil }
+func (p *passthroughWriter) Close() error { return nil }
+
+// WriteFilesToArchive writes every queued file into aw, hashing each one as
+// it streams past. Once the queue is drained a ChecksumsFile listing every
+// file's SHA-256 is added so recipients can verify the archive's contents;
+// files shared without an archive are published with their checksum instead.
+func WriteFilesToArchive(aw ArchiveWriter, files chan SourceFile) ([]FileInfo, error) {
+	_, passthrough := aw.(*passthroughWriter)
+	var infos []FileInfo
+	for src := range files {
+		if !passthrough && src.Name() == ChecksumsFile {
+			return nil, ErrReservedName
+		}
+		file, err := src.Open() // Open source file
+		if err != nil {
+			return nil, err
+		}
+
+		aBuffer, err := aw.Create(src.Name(), src.Size()) // Create file in archive with same name
+		if err != nil {
+			file.Close()
+			return nil, err
+		}
+		hash := sha256.New()
+		// Write file in chunks to aBuffer, hashing it on the way through
+		if err := writeBytesByChunk(file, io.MultiWriter(aBuffer, hash), 1024); err != nil {
+			file.Close()
+			return nil, err
+		}
+		// Flush the archive writer to write compressed bytes to buffer
+		// before moving onto the next file
+		if err := aw.Flush(); err != nil {
+			file.Close()
+			return nil, err
+		}
+		if err := file.Close(); err != nil {
+			return nil, err
+		}
+		infos = append(infos, FileInfo{
+			Name:     src.Name(),
+			Size:     src.Size(),
+			Checksum: hex.EncodeToString(hash.Sum(nil)),
+		})
+	}
+
+	if passthrough {
+		return infos, nil
+	}
+	var sums []byte
+	for _, info := range infos {
+		sums = append(sums, fmt.Sprintf("%s  %s\n", info.Checksum, info.Name)...)
+	}
+	sumsFile, err := aw.Create(ChecksumsFile, int64(len(sums)))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := sumsFile.Write(sums); err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
+
+// readerAtCloser is a random access reader over a buffer's plaintext.
+type readerAtCloser interface {
+	io.ReaderAt
+	io.Closer
+}
+
+// newReaderAt returns a random access reader over the buffer's plaintext.
+// The caller must not hold the buffer's lock, the reader takes it for each
+// read.
+func (b *OnionBuffer) newReaderAt() (readerAtCloser, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.destroyed {
+		return nil, ErrDestroyed
+	}
+	if !b.Sealed {
+		return &lockedReaderAt{b: b, r: nopReaderAtCloser{bytes.NewReader(b.Bytes)}}, nil
+	}
+	gcm, err := processEnclave.aead()
+	if err != nil {
+		return nil, err
+	}
+	return &lockedReaderAt{b: b, r: newChunkReaderAt(gcm, b.Bytes, b.Size)}, nil
+}
+
+type nopReaderAtCloser struct {
+	io.ReaderAt
+}
+
+func (nopReaderAtCloser) Close() error { return nil }
+
+// lockedReaderAt is the random access counterpart of lockedReader.
+type lockedReaderAt struct {
+	b *OnionBuffer
+	r readerAtCloser
+}
+
+func (r *lockedReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	r.b.RLock()
+	defer r.b.RUnlock()
+	if r.b.destroyed {
+		return 0, ErrDestroyed
+	}
+	return r.r.ReadAt(p, off)
+}
+
+func (r *lockedReaderAt) Close() error { return r.r.Close() }
+
+// File returns the file at index in Files. Encrypted buffers do not list
+// their files, so they return ErrFileNotFound for every index.
+func (b *OnionBuffer) File(index int) (FileInfo, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.destroyed {
+		return FileInfo{}, ErrDestroyed
+	}
+	if b.Encrypted || index < 0 || index >= len(b.Files) {
+		return FileInfo{}, ErrFileNotFound
+	}
+	return b.Files[index], nil
+}
+
+// WriteFileTo streams a single file, by its index in Files, out of the
+// buffer's archive to w. Zip entries are read in place while tar entries are
+// reached by streaming through the archive.
+func (b *OnionBuffer) WriteFileTo(w io.Writer, index int) (int64, error) {
+	b.RLock()
+	if index < 0 || index >= len(b.Files) {
+		b.RUnlock()
+		return 0, ErrFileNotFound
+	}
+	name := b.Files[index].Name
+	// Uploads may share a name, so find the right occurrence of it
+	skip := 0
+	for _, f := range b.Files[:index] {
+		if f.Name == name {
+			skip++
+		}
+	}
+	format, size := b.Format, b.Len()
+	b.RUnlock()
+
+	switch format {
+	case ArchiveNone:
+		r, err := b.newReader()
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+		return io.Copy(w, r)
+	case ArchiveTarGz, ArchiveTarZst:
+		r, err := b.newReader()
+		if err != nil {
+			return 0, err
+		}
+		defer r.Close()
+		var dr io.Reader
+		if format == ArchiveTarGz {
+			gr, err := gzip.NewReader(r)
+			if err != nil {
+				return 0, err
+			}
+			dr = gr
+		} else {
+			zr, err := zstd.NewReader(r)
+			if err != nil {
+				return 0, err
+			}
+			defer zr.Close()
+			dr = zr
+		}
+		tr := tar.NewReader(dr)
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				return 0, ErrFileNotFound
+			}
+			if err != nil {
+				return 0, err
+			}
+			if hdr.Name == name {
+				if skip == 0 {
+					return io.Copy(w, tr)
+				}
+				skip--
+			}
+		}
+	default:
+		ra, err := b.newReaderAt()
+		if err != nil {
+			return 0, err
+		}
+		defer ra.Close()
+		zr, err := zip.NewReader(ra, size)
+		if err != nil {
+			return 0, err
+		}
+		for _, f := range zr.File {
+			if f.Name != name {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return 0, err
+			}
+			defer rc.Close()
+			return io.Copy(w, rc)
+		}
+		return 0, ErrFileNotFound
+	}
+}
+
+// archiveOverhead is room left in an archive's buffer for the headers of
+// each file in it, and for its trailer.
+const archiveOverhead = 1 << 10
+
+// archiveBuffer collects the plaintext of an archive being written. Unlike
+// bytes.Buffer it wipes its old backing array whenever it grows, so no copy
+// of the plaintext is left behind for the garbage collector.
+type archiveBuffer struct {
+	b []byte
+}
+
+func newArchiveBuffer(size int64) *archiveBuffer {
+	return &archiveBuffer{b: make([]byte, 0, size)}
+}
+
+func (a *archiveBuffer) Write(p []byte) (int, error) {
+	if len(a.b)+len(p) > cap(a.b) {
+		grown := make([]byte, len(a.b), 2*cap(a.b)+len(p))
+		copy(grown, a.b)
+		wipe(a.b)
+		a.b = grown
+	}
+	a.b = append(a.b, p...)
+	return len(p), nil
+}
+
+// NewArchive writes files into a new archive of the given format and returns
+// an unnamed OnionBuffer holding it, along with its checksum and file list.
+func NewArchive(format ArchiveFormat, files []SourceFile) (*OnionBuffer, error) {
+	if format == ArchiveNone && len(files) != 1 {
+		return nil, ErrSingleFileOnly
+	}
+	queue := make(chan SourceFile, len(files)) // A channel that we can queue files on
+	for _, f := range files {
+		queue <- f
+	}
+	close(queue)
+
+	// Size the archive for its files up front, it rarely has to grow then
+	size := int64(archiveOverhead)
+	for _, f := range files {
+		size += f.Size() + archiveOverhead
+	}
+	aBuffer := newArchiveBuffer(size)
+	// Hash the archive as it is written so the checksum needs no second pass
+	aHash := sha256.New()
+	aw, err := NewArchiveWriter(format, io.MultiWriter(aBuffer, aHash))
+	if err != nil {
+		return nil, err
+	}
+	infos, err := WriteFilesToArchive(aw, queue)
+	if err == nil {
+		err = aw.Close()
+	}
+	if err != nil {
+		wipe(aBuffer.b)
+		return nil, err
+	}
+
+	b := &OnionBuffer{
+		Bytes:     aBuffer.b,
+		Checksum:  hex.EncodeToString(aHash.Sum(nil)),
+		Files:     infos,
+		Format:    format,
+		CreatedAt: time.Now(),
+	}
+	if format == ArchiveNone {
+		b.MIMEType = files[0].ContentType()
+	}
+	return b, nil
+}
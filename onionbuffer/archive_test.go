@@ -0,0 +1,327 @@
+package onionbuffer
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestParseArchiveFormat(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected ArchiveFormat
+		err      error
+	}{
+		{in: "", expected: ArchiveZip},
+		{in: "zip", expected: ArchiveZip},
+		{in: "tar.gz", expected: ArchiveTarGz},
+		{in: "tar.zst", expected: ArchiveTarZst},
+		{in: "none", expected: ArchiveNone},
+		{in: "rar", err: ErrUnknownArchiveFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			f, err := ParseArchiveFormat(tt.in)
+			if err != tt.err {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if f != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, f)
+			}
+		})
+	}
+}
+
+func TestWriteFilesToArchive(t *testing.T) {
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	contents := map[string][]byte{"gopher.jpg": testFile, "note.txt": []byte("This is a test")}
+
+	for _, format := range []ArchiveFormat{ArchiveZip, ArchiveTarGz, ArchiveTarZst} {
+		t.Run(string(format), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			aw, err := NewArchiveWriter(format, buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			infos, err := WriteFilesToArchive(aw, queueFiles(newFileHeaders(t, contents)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := aw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if len(infos) != 2 {
+				t.Fatalf("expected 2 file infos, got %d", len(infos))
+			}
+
+			extracted := extractArchive(t, format, buf.Bytes())
+			for name, content := range contents {
+				if !bytes.Equal(extracted[name], content) {
+					t.Errorf("%s does not match the uploaded file", name)
+				}
+			}
+			for _, info := range infos {
+				line := fmt.Sprintf("%s  %s\n", info.Checksum, info.Name)
+				if !strings.Contains(string(extracted[ChecksumsFile]), line) {
+					t.Errorf("expected %s to contain %q", ChecksumsFile, line)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteFilesToArchiveNone(t *testing.T) {
+	buf := new(bytes.Buffer)
+	aw, _ := NewArchiveWriter(ArchiveNone, buf)
+	infos, err := WriteFilesToArchive(aw, queueFiles(newFileHeaders(t, map[string][]byte{"note.txt": []byte("This is a test")})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "This is a test" || len(infos) != 1 {
+		t.Errorf("expected the file to be passed through untouched, got %q", buf.String())
+	}
+	b := &OnionBuffer{Name: "testing_none", Format: ArchiveNone, Files: infos, MIMEType: "text/plain"}
+	if b.FileName() != "note.txt" || b.ContentType() != "text/plain" {
+		t.Errorf("unexpected file name %q or content type %q", b.FileName(), b.ContentType())
+	}
+
+	aw, _ = NewArchiveWriter(ArchiveNone, new(bytes.Buffer))
+	files := newFileHeaders(t, map[string][]byte{"a.txt": []byte("a"), "b.txt": []byte("b")})
+	if _, err := WriteFilesToArchive(aw, queueFiles(files)); err != ErrSingleFileOnly {
+		t.Errorf("expected %v, got %v", ErrSingleFileOnly, err)
+	}
+}
+
+func TestWriteFilesToArchiveReservedName(t *testing.T) {
+	files := newFileHeaders(t, map[string][]byte{ChecksumsFile: []byte("0000  gopher.jpg\n")})
+	aw, _ := NewArchiveWriter(ArchiveZip, new(bytes.Buffer))
+	if _, err := WriteFilesToArchive(aw, queueFiles(files)); err != ErrReservedName {
+		t.Errorf("expected %v, got %v", ErrReservedName, err)
+	}
+	// Without an archive there is no checksums file to be confused with
+	aw, _ = NewArchiveWriter(ArchiveNone, new(bytes.Buffer))
+	if _, err := WriteFilesToArchive(aw, queueFiles(files)); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func queueFiles(files []*multipart.FileHeader) chan SourceFile {
+	queue := make(chan SourceFile, len(files))
+	for _, fh := range files {
+		queue <- UploadedFile(fh)
+	}
+	close(queue)
+	return queue
+}
+
+// extractArchive reads every file in an archive into memory.
+func extractArchive(t *testing.T, format ArchiveFormat, data []byte) map[string][]byte {
+	files := make(map[string][]byte)
+	if format == ArchiveZip {
+		zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, f := range zr.File {
+			rc, err := f.Open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			files[f.Name], _ = ioutil.ReadAll(rc)
+			rc.Close()
+		}
+		return files
+	}
+
+	var r io.Reader
+	if format == ArchiveTarGz {
+		gr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		r = gr
+	} else {
+		zr, err := zstd.NewReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name], _ = ioutil.ReadAll(tr)
+	}
+	return files
+}
+
+func TestWriteFileTo(t *testing.T) {
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	// Large enough for zip entries to span several sealed chunks
+	large := bytes.Repeat(testFile, 16)
+	contents := map[string][]byte{"gopher.jpg": large, "note.txt": []byte("This is a test")}
+
+	for _, format := range []ArchiveFormat{ArchiveZip, ArchiveTarGz, ArchiveTarZst, ArchiveNone} {
+		t.Run(string(format), func(t *testing.T) {
+			files := contents
+			if format == ArchiveNone {
+				files = map[string][]byte{"gopher.jpg": large}
+			}
+			buf := new(bytes.Buffer)
+			aw, _ := NewArchiveWriter(format, buf)
+			infos, err := WriteFilesToArchive(aw, queueFiles(newFileHeaders(t, files)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := aw.Close(); err != nil {
+				t.Fatal(err)
+			}
+			b := &OnionBuffer{Name: "testing_write_file", Bytes: buf.Bytes(), Files: infos, Format: format}
+			if err := b.Seal(); err != nil {
+				t.Fatal(err)
+			}
+
+			for i, info := range infos {
+				out := new(bytes.Buffer)
+				if _, err := b.WriteFileTo(out, i); err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(out.Bytes(), files[info.Name]) {
+					t.Errorf("%s does not match the uploaded file", info.Name)
+				}
+			}
+			if _, err := b.WriteFileTo(ioutil.Discard, len(infos)); err != ErrFileNotFound {
+				t.Errorf("expected %v, got %v", ErrFileNotFound, err)
+			}
+		})
+	}
+}
+
+func TestFile(t *testing.T) {
+	b := &OnionBuffer{Name: "testing_file", Files: []FileInfo{{Name: "a.txt", Size: 1}}}
+	if info, err := b.File(0); err != nil || info.Name != "a.txt" {
+		t.Errorf("expected a.txt, got %+v and %v", info, err)
+	}
+	if _, err := b.File(1); err != ErrFileNotFound {
+		t.Errorf("expected %v, got %v", ErrFileNotFound, err)
+	}
+	b.Encrypted = true
+	if _, err := b.File(0); err != ErrFileNotFound {
+		t.Errorf("expected encrypted files to be unlisted, got %v", err)
+	}
+	b.Destroy()
+	if _, err := b.File(0); err != ErrDestroyed {
+		t.Errorf("expected %v, got %v", ErrDestroyed, err)
+	}
+}
+
+func TestArchiveBufferWipesOnGrowth(t *testing.T) {
+	a := newArchiveBuffer(4)
+	a.Write([]byte("abcd"))
+	old := a.b
+	a.Write([]byte("efgh"))
+	if string(a.b) != "abcdefgh" {
+		t.Errorf("expected abcdefgh, got %q", a.b)
+	}
+	if !bytes.Equal(old, make([]byte, 4)) {
+		t.Errorf("expected the outgrown array to be wiped, got %q", old)
+	}
+}
+
+// memFile is a SourceFile held in memory.
+type memFile struct {
+	name    string
+	content []byte
+}
+
+func (m memFile) Name() string                 { return m.name }
+func (m memFile) Size() int64                  { return int64(len(m.content)) }
+func (m memFile) ContentType() string          { return extensionMIMEType(m.name) }
+func (m memFile) Open() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(m.content)), nil }
+
+// archiveRoundTrip archives files in format and checks both extracting the
+// archive and downloading each file from a sealed buffer give them back.
+func archiveRoundTrip(t *testing.T, format ArchiveFormat, files []memFile) {
+	queue := make(chan SourceFile, len(files))
+	for _, f := range files {
+		queue <- f
+	}
+	close(queue)
+	buf := new(bytes.Buffer)
+	aw, err := NewArchiveWriter(format, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	infos, err := WriteFilesToArchive(aw, queue)
+	if err != nil {
+		// Refusing a file is fine, mangling it is not
+		return
+	}
+	if err := aw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var extracted map[string][]byte
+	if format != ArchiveNone {
+		extracted = extractArchive(t, format, buf.Bytes())
+	}
+	b := &OnionBuffer{Name: "testing_round_trip", Bytes: buf.Bytes(), Files: infos, Format: format}
+	if err := b.Seal(); err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range files {
+		if format != ArchiveNone && !bytes.Equal(extracted[f.name], f.content) {
+			t.Errorf("%s: extracted %q does not match the uploaded file", format, f.name)
+		}
+		out := new(bytes.Buffer)
+		if _, err := b.WriteFileTo(out, i); err != nil {
+			t.Fatalf("%s: downloading %q: %v", format, f.name, err)
+		}
+		if !bytes.Equal(out.Bytes(), f.content) {
+			t.Errorf("%s: downloaded %q does not match the uploaded file", format, f.name)
+		}
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	roundTrip := func(first, second []byte) bool {
+		files := []memFile{{"first.bin", first}, {"second.bin", second}}
+		for _, format := range []ArchiveFormat{ArchiveZip, ArchiveTarGz, ArchiveTarZst} {
+			archiveRoundTrip(t, format, files)
+		}
+		archiveRoundTrip(t, ArchiveNone, files[:1])
+		return !t.Failed()
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzWriteFilesToArchive(f *testing.F) {
+	f.Add("note.txt", []byte("This is a test"))
+	f.Add("", []byte{})
+	f.Add("dir/", []byte("x"))
+	f.Add(ChecksumsFile, []byte("not a checksum"))
+	f.Fuzz(func(t *testing.T, name string, content []byte) {
+		for _, format := range []ArchiveFormat{ArchiveZip, ArchiveTarGz, ArchiveTarZst, ArchiveNone} {
+			archiveRoundTrip(t, format, []memFile{{name, content}})
+		}
+	})
+}
@@ -1,42 +1,40 @@
 package onionbuffer
 
 import (
-	"bufio"
-	"bytes"
-	"crypto/md5"
+	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"io"
-	"syscall"
 )
 
+// ChecksumsFile is the name of the per file checksum listing written at the
+// end of every share archive, in the format of sha256sum(1).
+const ChecksumsFile = "SHA256SUMS"
+
+// ErrInvalidChecksum is returned when a buffer's bytes no longer hash to the
+// checksum recorded at upload.
+var ErrInvalidChecksum = errors.New("checksum does not match")
+
+// GetChecksum streams the buffer's plaintext through SHA-256 and returns the
+// hex encoded digest.
 func (b *OnionBuffer) GetChecksum() (string, error) {
-	b.Lock()
-	var count int
-	var err error
-	hash := md5.New()
-	reader := bufio.NewReader(bytes.NewReader(b.Bytes))
-	chunk := make([]byte, 1)
-	if err := syscall.Mlock(chunk); err != nil { // Lock memory allotted to chunk from being used in SWAP
+	r, err := b.newReader()
+	if err != nil {
 		return "", err
 	}
-	for {
-		if count, err = reader.Read(chunk); err != nil {
-			break
-		}
-		_, err := hash.Write(chunk[:count])
-		if err != nil {
-			return "", err
-		}
-	}
-	if err != io.EOF {
+	defer r.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, r); err != nil {
 		return "", err
 	}
-	b.Unlock()
-	hashInBytes := hash.Sum(nil)[:16]
-	return hex.EncodeToString(hashInBytes), nil
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
+// ValidateChecksum recomputes the buffer's checksum and compares it to the
+// one recorded at upload.
 func (b *OnionBuffer) ValidateChecksum() (bool, error) {
 	chksm, err := b.GetChecksum()
 	if err != nil {
@@ -44,3 +42,28 @@ func (b *OnionBuffer) ValidateChecksum() (bool, error) {
 	}
 	return subtle.ConstantTimeCompare([]byte(b.Checksum), []byte(chksm)) == 1, nil
 }
+
+// MatchesChecksum reports whether data hashes to the buffer's checksum. It is
+// used on the decrypted bytes of password protected buffers.
+func (b *OnionBuffer) MatchesChecksum(data []byte) bool {
+	sum := sha256.Sum256(data)
+	return subtle.ConstantTimeCompare([]byte(b.Checksum), []byte(hex.EncodeToString(sum[:]))) == 1
+}
+
+// DigestHeader returns the buffer's checksum formatted for the Repr-Digest
+// and legacy Digest headers.
+func (b *OnionBuffer) DigestHeader() (repr string, legacy string, err error) {
+	return DigestHeaders(b.Checksum)
+}
+
+// DigestHeaders formats a hex encoded SHA-256 checksum for the Repr-Digest
+// header (RFC 9530). The legacy Digest header (RFC 3230) uses the same
+// base64 value.
+func DigestHeaders(checksum string) (repr string, legacy string, err error) {
+	sum, err := hex.DecodeString(checksum)
+	if err != nil {
+		return "", "", err
+	}
+	enc := base64.StdEncoding.EncodeToString(sum)
+	return fmt.Sprintf("sha-256=:%s:", enc), fmt.Sprintf("SHA-256=%s", enc), nil
+}
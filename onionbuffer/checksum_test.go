@@ -35,6 +35,37 @@ func TestGetChecksum(t *testing.T) {
 	}
 }
 
+func TestGetChecksumSealed(t *testing.T) {
+	b := OnionBuffer{Bytes: []byte("This is a test")}
+	if err := b.Seal(); err != nil {
+		t.Fatal(err)
+	}
+	chksm, err := b.GetChecksum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chksm != "c7be1ed902fb8dd4d48997c6452f5d7e509fbcdbe2808b16bcf4edce4c07d14e" {
+		t.Errorf("unexpected checksum %s", chksm)
+	}
+}
+
+func TestDigestHeader(t *testing.T) {
+	b := OnionBuffer{Checksum: "c7be1ed902fb8dd4d48997c6452f5d7e509fbcdbe2808b16bcf4edce4c07d14e"}
+	repr, legacy, err := b.DigestHeader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repr != "sha-256=:x74e2QL7jdTUiZfGRS9dflCfvNvigIsWvPTtzkwH0U4=:" {
+		t.Errorf("unexpected Repr-Digest %s", repr)
+	}
+	if legacy != "SHA-256=x74e2QL7jdTUiZfGRS9dflCfvNvigIsWvPTtzkwH0U4=" {
+		t.Errorf("unexpected Digest %s", legacy)
+	}
+	if !b.MatchesChecksum([]byte("This is a test")) {
+		t.Error("expected bytes to match checksum")
+	}
+}
+
 func BenchmarkGetChecksum(b *testing.B) {
 	ob := OnionBuffer{Bytes: []byte("Testing checksum")}
 	for n := 0; n < b.N; n++ {
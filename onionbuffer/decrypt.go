@@ -4,6 +4,16 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/sha256"
+	"errors"
+)
+
+var (
+	// ErrCiphertextTooShort is returned when decrypting data too short to
+	// hold a nonce and authentication tag, so never produced by Encrypt.
+	ErrCiphertextTooShort = errors.New("ciphertext is too short")
+	// ErrBadPassword is returned when the passphrase is wrong or the
+	// ciphertext was tampered with, which cannot be told apart.
+	ErrBadPassword = errors.New("wrong passphrase or corrupted ciphertext")
 )
 
 func Decrypt(data []byte, passphrase string) ([]byte, error) {
@@ -17,10 +27,13 @@ func Decrypt(data []byte, passphrase string) ([]byte, error) {
 		return nil, err
 	}
 	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize+gcm.Overhead() {
+		return nil, ErrCiphertextTooShort
+	}
 	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
 	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
-		return nil, err
+		return nil, ErrBadPassword
 	}
 	return plaintext, nil
 }
@@ -1,6 +1,10 @@
 package onionbuffer
 
-import "testing"
+import (
+	"bytes"
+	"testing"
+	"testing/quick"
+)
 
 func TestDecrypt(t *testing.T) {
 	secretMessage := []byte("This is a secret message")
@@ -23,3 +27,41 @@ func BenchmarkDecrypt(b *testing.B) {
 		Decrypt(encryptedBytes, password)
 	}
 }
+
+func TestDecryptInvalid(t *testing.T) {
+	encryptedBytes, _ := Encrypt([]byte("This is a secret message"), "hunter2")
+	if _, err := Decrypt(encryptedBytes, "hunter3"); err != ErrBadPassword {
+		t.Errorf("expected %v for a wrong password, got %v", ErrBadPassword, err)
+	}
+	for _, data := range [][]byte{nil, []byte("short"), encryptedBytes[:27]} {
+		if _, err := Decrypt(data, "hunter2"); err != ErrCiphertextTooShort {
+			t.Errorf("%d bytes: expected %v, got %v", len(data), ErrCiphertextTooShort, err)
+		}
+	}
+}
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	roundTrip := func(data []byte, passphrase string) bool {
+		encryptedBytes, err := Encrypt(data, passphrase)
+		if err != nil {
+			return false
+		}
+		decryptedBytes, err := Decrypt(encryptedBytes, passphrase)
+		return err == nil && bytes.Equal(decryptedBytes, data)
+	}
+	if err := quick.Check(roundTrip, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func FuzzDecrypt(f *testing.F) {
+	encryptedBytes, _ := Encrypt([]byte("This is a secret message"), "hunter2")
+	f.Add(encryptedBytes, "hunter2")
+	f.Add([]byte{}, "")
+	f.Add(encryptedBytes[:12], "hunter2")
+	f.Fuzz(func(t *testing.T, data []byte, passphrase string) {
+		if _, err := Decrypt(data, passphrase); err != nil && err != ErrCiphertextTooShort && err != ErrBadPassword {
+			t.Errorf("unexpected error %v", err)
+		}
+	})
+}
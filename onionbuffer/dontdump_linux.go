@@ -0,0 +1,30 @@
+//go:build linux
+
+package onionbuffer
+
+import (
+	"os"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// dontDump excludes the memory of b from core dumps. madvise only takes
+// whole pages while buffers rarely start or end on one, so the pages b
+// spans are advised, along with whatever else shares the first and last.
+func dontDump(b []byte) error {
+	if len(b) == 0 {
+		return nil
+	}
+	pageSize := uintptr(os.Getpagesize())
+	start := uintptr(unsafe.Pointer(&b[0]))
+	end := (start + uintptr(len(b)) + pageSize - 1) &^ (pageSize - 1)
+	start &^= pageSize - 1
+	_, _, errno := unix.Syscall(unix.SYS_MADVISE, start, end-start, unix.MADV_DONTDUMP)
+	runtime.KeepAlive(b)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
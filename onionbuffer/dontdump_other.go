@@ -0,0 +1,9 @@
+//go:build !linux
+
+package onionbuffer
+
+// dontDump does nothing, since only Linux can exclude memory from core
+// dumps. onionbox disables core dumps of the whole process instead.
+func dontDump(b []byte) error {
+	return nil
+}
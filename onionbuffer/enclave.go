@@ -0,0 +1,292 @@
+package onionbuffer
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/sys/unix"
+)
+
+// chunkSize is the amount of plaintext sealed under a single nonce. Only one
+// chunk of an OnionBuffer is ever decrypted in memory at a time.
+const chunkSize = 64 << 10
+
+// keySize is the size of the ephemeral enclave key (AES-256).
+const keySize = 32
+
+var (
+	errEnclaveDestroyed = errors.New("enclave key has been destroyed")
+	errCorruptChunk     = errors.New("sealed chunk is corrupt")
+)
+
+// enclave holds the per-process ephemeral key used to seal OnionBuffers at
+// rest. The key lives in its own mmap'd page which is mlocked, excluded from
+// core dumps and kept PROT_NONE whenever it is not being read.
+// ref: https://github.com/awnumar/memguard
+type enclave struct {
+	sync.Mutex
+	page      []byte
+	destroyed bool
+}
+
+var processEnclave = new(enclave)
+
+// init lazily allocates the guarded page and fills it with a random key.
+func (e *enclave) init() error {
+	if e.page != nil {
+		return nil
+	}
+	page, err := Allocate(os.Getpagesize())
+	if err != nil {
+		return err
+	}
+	if err := dontDump(page); err != nil {
+		_ = Unallocate(page)
+		return err
+	}
+	if err := unix.Mlock(page); err != nil {
+		_ = Unallocate(page)
+		return err
+	}
+	if _, err := io.ReadFull(rand.Reader, page[:keySize]); err != nil {
+		_ = Unallocate(page)
+		return err
+	}
+	if err := unix.Mprotect(page, unix.PROT_NONE); err != nil {
+		_ = Unallocate(page)
+		return err
+	}
+	e.page = page
+	return nil
+}
+
+// aead briefly unprotects the key page to build an AES-GCM instance.
+func (e *enclave) aead() (cipher.AEAD, error) {
+	e.Lock()
+	defer e.Unlock()
+	if e.destroyed {
+		return nil, errEnclaveDestroyed
+	}
+	if err := e.init(); err != nil {
+		return nil, err
+	}
+	if err := unix.Mprotect(e.page, unix.PROT_READ); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(e.page[:keySize])
+	if perr := unix.Mprotect(e.page, unix.PROT_NONE); perr != nil && err == nil {
+		err = perr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// destroy wipes and unmaps the key page. Anything sealed under it can no
+// longer be opened.
+func (e *enclave) destroy() error {
+	e.Lock()
+	defer e.Unlock()
+	e.destroyed = true
+	if e.page == nil {
+		return nil
+	}
+	if err := unix.Mprotect(e.page, unix.PROT_READ|unix.PROT_WRITE); err != nil {
+		return err
+	}
+	wipe(e.page)
+	_ = unix.Munlock(e.page)
+	err := Unallocate(e.page)
+	e.page = nil
+	return err
+}
+
+// DestroyEnclave wipes the process enclave key. It should only be called on
+// shutdown, after every OnionBuffer has been destroyed.
+func DestroyEnclave() error {
+	return processEnclave.destroy()
+}
+
+// sealedChunkLen returns the length of a sealed chunk holding n plaintext bytes.
+func sealedChunkLen(gcm cipher.AEAD, n int) int {
+	return gcm.NonceSize() + n + gcm.Overhead()
+}
+
+// seal encrypts plaintext chunk by chunk. Each chunk gets a random nonce and
+// is bound to its index so chunks cannot be reordered.
+func seal(gcm cipher.AEAD, plaintext []byte) ([]byte, error) {
+	chunks := (len(plaintext) + chunkSize - 1) / chunkSize
+	out := make([]byte, 0, len(plaintext)+chunks*(gcm.NonceSize()+gcm.Overhead()))
+	ad := make([]byte, 8)
+	for i := 0; i*chunkSize < len(plaintext); i++ {
+		end := (i + 1) * chunkSize
+		if end > len(plaintext) {
+			end = len(plaintext)
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return nil, err
+		}
+		binary.BigEndian.PutUint64(ad, uint64(i))
+		out = append(out, nonce...)
+		out = gcm.Seal(out, nonce, plaintext[i*chunkSize:end], ad)
+	}
+	return out, nil
+}
+
+// chunkReader decrypts a sealed OnionBuffer one chunk at a time into a
+// single mlocked scratch buffer which is wiped between chunks.
+type chunkReader struct {
+	gcm    cipher.AEAD
+	sealed []byte
+	index  uint64
+	buf    []byte
+	plain  []byte
+}
+
+func newChunkReader(gcm cipher.AEAD, sealed []byte) *chunkReader {
+	r := &chunkReader{gcm: gcm, sealed: sealed, buf: make([]byte, 0, chunkSize)}
+	_ = unix.Mlock(r.buf[:cap(r.buf)])
+	return r
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	if len(r.plain) == 0 {
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+// next opens the following sealed chunk into the scratch buffer.
+func (r *chunkReader) next() error {
+	wipe(r.buf[:cap(r.buf)])
+	if len(r.sealed) == 0 {
+		return io.EOF
+	}
+	n := sealedChunkLen(r.gcm, chunkSize)
+	if n > len(r.sealed) {
+		n = len(r.sealed)
+	}
+	nonceSize := r.gcm.NonceSize()
+	if n < nonceSize+r.gcm.Overhead() {
+		return errCorruptChunk
+	}
+	ad := make([]byte, 8)
+	binary.BigEndian.PutUint64(ad, r.index)
+	plain, err := r.gcm.Open(r.buf[:0], r.sealed[:nonceSize], r.sealed[nonceSize:n], ad)
+	if err != nil {
+		return errCorruptChunk
+	}
+	r.sealed = r.sealed[n:]
+	r.plain = plain
+	r.index++
+	return nil
+}
+
+// Close wipes and unlocks the scratch buffer.
+func (r *chunkReader) Close() error {
+	wipe(r.buf[:cap(r.buf)])
+	r.plain = nil
+	_ = unix.Munlock(r.buf[:cap(r.buf)])
+	return nil
+}
+
+// wipe zeroes b in place.
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Wipe zeroes b in place, for copies of a buffer's bytes such as those
+// returned by ReadAll and Decrypt once they have been used.
+func Wipe(b []byte) {
+	wipe(b)
+}
+
+// chunkReaderAt gives random access into a sealed OnionBuffer, keeping only
+// the most recently opened chunk decrypted.
+type chunkReaderAt struct {
+	gcm    cipher.AEAD
+	sealed []byte
+	size   int64
+	buf    []byte
+	plain  []byte
+	cached int64
+}
+
+func newChunkReaderAt(gcm cipher.AEAD, sealed []byte, size int64) *chunkReaderAt {
+	r := &chunkReaderAt{gcm: gcm, sealed: sealed, size: size, buf: make([]byte, 0, chunkSize), cached: -1}
+	_ = unix.Mlock(r.buf[:cap(r.buf)])
+	return r
+}
+
+func (r *chunkReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+	var n int
+	for n < len(p) && off < r.size {
+		if err := r.open(off / chunkSize); err != nil {
+			return n, err
+		}
+		m := copy(p[n:], r.plain[off%chunkSize:])
+		n += m
+		off += int64(m)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// open decrypts the chunk at index into the scratch buffer, unless it is
+// already there.
+func (r *chunkReaderAt) open(index int64) error {
+	if index == r.cached {
+		return nil
+	}
+	wipe(r.buf[:cap(r.buf)])
+	r.cached = -1
+	start := index * int64(sealedChunkLen(r.gcm, chunkSize))
+	if start >= int64(len(r.sealed)) {
+		return errCorruptChunk
+	}
+	end := start + int64(sealedChunkLen(r.gcm, chunkSize))
+	if end > int64(len(r.sealed)) {
+		end = int64(len(r.sealed))
+	}
+	chunk := r.sealed[start:end]
+	nonceSize := r.gcm.NonceSize()
+	if len(chunk) < nonceSize+r.gcm.Overhead() {
+		return errCorruptChunk
+	}
+	ad := make([]byte, 8)
+	binary.BigEndian.PutUint64(ad, uint64(index))
+	plain, err := r.gcm.Open(r.buf[:0], chunk[:nonceSize], chunk[nonceSize:], ad)
+	if err != nil {
+		return errCorruptChunk
+	}
+	r.plain = plain
+	r.cached = index
+	return nil
+}
+
+// Close wipes and unlocks the scratch buffer.
+func (r *chunkReaderAt) Close() error {
+	wipe(r.buf[:cap(r.buf)])
+	r.plain = nil
+	_ = unix.Munlock(r.buf[:cap(r.buf)])
+	return nil
+}
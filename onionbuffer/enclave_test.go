@@ -0,0 +1,56 @@
+package onionbuffer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+)
+
+func TestSeal(t *testing.T) {
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	// Repeat the test file so the buffer spans several chunks
+	plaintext := bytes.Repeat(testFile, 16)
+	expected := append([]byte(nil), plaintext...)
+
+	b := &OnionBuffer{Name: "testing_seal", Bytes: plaintext}
+	if err := b.Seal(); err != nil {
+		t.Fatal(err)
+	}
+	if !b.Sealed {
+		t.Error("expected onionbuffer to be sealed")
+	}
+	if b.Len() != int64(len(expected)) {
+		t.Errorf("expected length %d, got %d", len(expected), b.Len())
+	}
+	if bytes.Contains(b.Bytes, expected[:64]) {
+		t.Error("sealed bytes contain plaintext")
+	}
+
+	out := new(bytes.Buffer)
+	if _, err := b.WriteTo(out); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out.Bytes(), expected) {
+		t.Error("streamed bytes do not match the original plaintext")
+	}
+}
+
+func TestSealCorrupt(t *testing.T) {
+	b := &OnionBuffer{Name: "testing_seal_corrupt", Bytes: []byte("This is a secret message")}
+	if err := b.Seal(); err != nil {
+		t.Fatal(err)
+	}
+	b.Bytes[len(b.Bytes)-1] ^= 0xff
+	if _, err := b.WriteTo(ioutil.Discard); err != errCorruptChunk {
+		t.Errorf("expected %v, got %v", errCorruptChunk, err)
+	}
+}
+
+func BenchmarkWriteTo(b *testing.B) {
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	ob := &OnionBuffer{Bytes: testFile}
+	_ = ob.Seal()
+	for n := 0; n < b.N; n++ {
+		ob.WriteTo(ioutil.Discard)
+	}
+}
@@ -25,3 +25,18 @@ func Encrypt(data []byte, passphrase string) ([]byte, error) {
 	ciphertext := gcm.Seal(nonce, nonce, data, nil)
 	return ciphertext, nil
 }
+
+// SetPassword encrypts the buffer's bytes with passphrase, wiping the
+// plaintext. Recipients must then enter the passphrase to download it.
+func (b *OnionBuffer) SetPassword(passphrase string) error {
+	b.Lock()
+	defer b.Unlock()
+	ciphertext, err := Encrypt(b.Bytes, passphrase)
+	if err != nil {
+		return err
+	}
+	wipe(b.Bytes)
+	b.Bytes = ciphertext
+	b.Encrypted = true
+	return nil
+}
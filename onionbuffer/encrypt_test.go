@@ -19,3 +19,20 @@ func BenchmarkEncrypt(b *testing.B) {
 		Encrypt(secretMessage, password)
 	}
 }
+
+func TestSetPassword(t *testing.T) {
+	b := &OnionBuffer{Bytes: []byte("Top secret information")}
+	if err := b.SetPassword("test"); err != nil {
+		t.Fatal(err)
+	}
+	if !b.Encrypted {
+		t.Error("expected the buffer to be marked encrypted")
+	}
+	plain, err := Decrypt(b.Bytes, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(plain) != "Top secret information" {
+		t.Errorf("unexpected plaintext %q", plain)
+	}
+}
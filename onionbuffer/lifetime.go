@@ -0,0 +1,133 @@
+package onionbuffer
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotYetAvailable = errors.New("share is not available yet")
+	ErrExpired         = errors.New("share has expired")
+	ErrInvalidLifetime = errors.New("share would expire before it becomes available")
+)
+
+// Lifetime is a snapshot of a buffer's lifetime policies, for display to
+// recipients.
+type Lifetime struct {
+	NotBefore     time.Time
+	ExpiresAt     time.Time
+	IdleTimeout   time.Duration
+	BurnAfterRead bool
+	DownloadsLeft int64
+	Available     bool
+}
+
+// Lifetime returns a snapshot of b's lifetime policies. DownloadsLeft is -1
+// when downloads are unlimited.
+func (b *OnionBuffer) Lifetime() Lifetime {
+	b.RLock()
+	defer b.RUnlock()
+	l := Lifetime{
+		NotBefore:     b.NotBefore,
+		IdleTimeout:   b.IdleTimeout,
+		BurnAfterRead: b.BurnAfterRead,
+		DownloadsLeft: -1,
+		Available:     b.available(time.Now()) == nil,
+	}
+	if b.Expire {
+		l.ExpiresAt = b.ExpiresAt
+	}
+	if b.DownloadLimit != 0 {
+		l.DownloadsLeft = b.DownloadLimit - b.Downloads
+	}
+	return l
+}
+
+// Available returns ErrNotYetAvailable while b is embargoed, ErrExpired
+// once it has expired and ErrDestroyed once it has been destroyed.
+func (b *OnionBuffer) Available() error {
+	b.RLock()
+	defer b.RUnlock()
+	return b.available(time.Now())
+}
+
+// available is Available for callers already holding the lock.
+func (b *OnionBuffer) available(now time.Time) error {
+	// Destroy clears the policies below, which would let anything through
+	if b.destroyed {
+		return ErrDestroyed
+	}
+	if now.Before(b.NotBefore) {
+		return ErrNotYetAvailable
+	}
+	if b.Expire && !now.Before(b.ExpiresAt) {
+		return ErrExpired
+	}
+	return nil
+}
+
+// SetDeadline sets an absolute time at which b is wiped. An earlier deadline
+// already set is kept.
+func (b *OnionBuffer) SetDeadline(deadline time.Time) error {
+	b.Lock()
+	defer b.Unlock()
+	if !deadline.After(time.Now()) || !deadline.After(b.NotBefore) {
+		return ErrInvalidLifetime
+	}
+	if b.Deadline.IsZero() || deadline.Before(b.Deadline) {
+		b.Deadline = deadline
+	}
+	b.updateExpiry()
+	return nil
+}
+
+// SetIdleTimeout wipes b once it has gone d without a successful download.
+// The timer starts when b becomes available.
+func (b *OnionBuffer) SetIdleTimeout(d time.Duration) error {
+	if d <= 0 {
+		return ErrInvalidLifetime
+	}
+	b.Lock()
+	defer b.Unlock()
+	b.IdleTimeout = d
+	b.updateExpiry()
+	return nil
+}
+
+// SetNotBefore embargoes b until t. Downloads are refused until then.
+func (b *OnionBuffer) SetNotBefore(t time.Time) error {
+	b.Lock()
+	defer b.Unlock()
+	if !b.Deadline.IsZero() && !b.Deadline.After(t) {
+		return ErrInvalidLifetime
+	}
+	b.NotBefore = t
+	b.updateExpiry()
+	return nil
+}
+
+// updateExpiry derives the effective expiry from b's deadline and idle
+// timeout, whichever comes first. The caller must hold the lock.
+func (b *OnionBuffer) updateExpiry() {
+	var at time.Time
+	if !b.Deadline.IsZero() {
+		at = b.Deadline
+	}
+	if b.IdleTimeout > 0 {
+		start := b.LastDownload
+		if start.IsZero() {
+			start = b.CreatedAt
+			if start.IsZero() {
+				start = time.Now()
+			}
+			if b.NotBefore.After(start) {
+				start = b.NotBefore
+			}
+		}
+		if idle := start.Add(b.IdleTimeout); at.IsZero() || idle.Before(at) {
+			at = idle
+		}
+	}
+	b.Expire = !at.IsZero()
+	b.ExpiresAt = at
+}
@@ -0,0 +1,114 @@
+package onionbuffer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotBefore(t *testing.T) {
+	b := &OnionBuffer{Name: "testing_not_before", CreatedAt: time.Now()}
+	if err := b.SetNotBefore(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ClaimDownload(); err != ErrNotYetAvailable {
+		t.Errorf("expected %v, got %v", ErrNotYetAvailable, err)
+	}
+	if err := b.SetDeadline(time.Now().Add(time.Minute)); err != ErrInvalidLifetime {
+		t.Errorf("expected %v for a deadline before the embargo, got %v", ErrInvalidLifetime, err)
+	}
+
+	b.NotBefore = time.Now().Add(-time.Second)
+	if err := b.ClaimDownload(); err != nil {
+		t.Errorf("expected embargo to have lifted, got %v", err)
+	}
+}
+
+func TestSetDeadline(t *testing.T) {
+	b := &OnionBuffer{Name: "testing_deadline"}
+	if err := b.SetDeadline(time.Now().Add(-time.Minute)); err != ErrInvalidLifetime {
+		t.Errorf("expected %v for a past deadline, got %v", ErrInvalidLifetime, err)
+	}
+	first := time.Now().Add(time.Hour)
+	if err := b.SetDeadline(first); err != nil {
+		t.Fatal(err)
+	}
+	// A later deadline does not extend the share's lifetime
+	if err := b.SetDeadline(first.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if !b.Expire || !b.ExpiresAt.Equal(first) {
+		t.Errorf("expected expiry at %v, got %v", first, b.ExpiresAt)
+	}
+}
+
+func TestIdleTimeout(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	b := &OnionBuffer{Name: "testing_idle", CreatedAt: created}
+	if err := b.SetDeadline(time.Now().Add(24 * time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetIdleTimeout(90 * time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if !b.ExpiresAt.Equal(created.Add(90 * time.Minute)) {
+		t.Errorf("expected idle expiry from creation, got %v", b.ExpiresAt)
+	}
+
+	// A successful download restarts the idle timeout
+	if err := b.ClaimDownload(); err != nil {
+		t.Fatal(err)
+	}
+	b.FinishDownload(false, true)
+	if !b.ExpiresAt.Equal(b.LastDownload.Add(90 * time.Minute)) {
+		t.Errorf("expected idle expiry from last download, got %v", b.ExpiresAt)
+	}
+}
+
+func TestBurnAfterRead(t *testing.T) {
+	b := &OnionBuffer{Name: "testing_burn", BurnAfterRead: true}
+	if err := b.ClaimDownload(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.ClaimDownload(); err != ErrLimitReached {
+		t.Errorf("expected concurrent download to be refused, got %v", err)
+	}
+	// A failed download does not burn the share
+	if b.FinishDownload(true, false) {
+		t.Error("buffer should not burn after a failed download")
+	}
+	if err := b.ClaimDownload(); err != nil {
+		t.Fatal(err)
+	}
+	if !b.FinishDownload(false, false) {
+		t.Error("expected buffer to burn after its first successful download")
+	}
+	if err := b.ClaimDownload(); err != ErrLimitReached {
+		t.Errorf("expected burnt buffer to refuse downloads, got %v", err)
+	}
+}
+
+func TestDownloadable(t *testing.T) {
+	b := &OnionBuffer{Name: "testing_downloadable", DownloadLimit: 1}
+	if err := b.Downloadable(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Downloadable(); err != nil {
+		t.Errorf("expected checking not to claim a download, got %v", err)
+	}
+	if err := b.ClaimDownload(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Downloadable(); err != ErrLimitReached {
+		t.Errorf("expected %v, got %v", ErrLimitReached, err)
+	}
+	b = &OnionBuffer{Name: "testing_downloadable", NotBefore: time.Now().Add(time.Hour)}
+	if err := b.Downloadable(); err != ErrNotYetAvailable {
+		t.Errorf("expected %v, got %v", ErrNotYetAvailable, err)
+	}
+	// Destroying a buffer clears its limits, which must not free it
+	b = &OnionBuffer{Name: "testing_downloadable", DownloadLimit: 1}
+	b.Destroy()
+	if err := b.ClaimDownload(); err != ErrDestroyed {
+		t.Errorf("expected %v, got %v", ErrDestroyed, err)
+	}
+}
@@ -0,0 +1,162 @@
+package onionbuffer
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+// ManifestVersion is bumped whenever the manifest format changes.
+const ManifestVersion = 2
+
+var (
+	ErrInvalidSignature = errors.New("manifest signature is invalid")
+	errNoPEMBlock       = errors.New("no PEM block found in key file")
+)
+
+// Manifest lists everything a recipient needs to check that a share came
+// from this operator and was not swapped. It only holds what is fixed once
+// the share is stored, so a manifest and a signature fetched on either side
+// of a download still match: Deadline is the share's hard deadline, not its
+// expiry, which an idle timeout moves on every download.
+type Manifest struct {
+	Version   int        `json:"version"`
+	ShareID   string     `json:"share_id"`
+	Checksum  string     `json:"sha256"`
+	Files     []FileInfo `json:"files"`
+	CreatedAt time.Time  `json:"created_at"`
+	NotBefore *time.Time `json:"not_before,omitempty"`
+	Deadline  *time.Time `json:"deadline,omitempty"`
+}
+
+// Manifest builds the manifest describing b.
+func (b *OnionBuffer) Manifest() *Manifest {
+	b.RLock()
+	defer b.RUnlock()
+	m := &Manifest{
+		Version:   ManifestVersion,
+		ShareID:   b.Name,
+		Checksum:  b.Checksum,
+		Files:     b.Files,
+		CreatedAt: b.CreatedAt.UTC(),
+	}
+	if !b.NotBefore.IsZero() {
+		notBefore := b.NotBefore.UTC()
+		m.NotBefore = &notBefore
+	}
+	if !b.Deadline.IsZero() {
+		deadline := b.Deadline.UTC()
+		m.Deadline = &deadline
+	}
+	return m
+}
+
+// Marshal encodes m into the exact bytes which are signed and served.
+func (m *Manifest) Marshal() ([]byte, error) {
+	return json.MarshalIndent(m, "", "  ")
+}
+
+// SignManifest signs the marshalled manifest data with key. The signature is
+// returned base64 encoded, ready to be served as a file.
+func SignManifest(data []byte, key ed25519.PrivateKey) []byte {
+	sig := ed25519.Sign(key, data)
+	return []byte(base64.StdEncoding.EncodeToString(sig) + "\n")
+}
+
+// VerifyManifest checks sig against the exact manifest bytes in data and
+// returns the parsed manifest if it is valid.
+func VerifyManifest(data, sig []byte, pub ed25519.PublicKey) (*Manifest, error) {
+	rawSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil {
+		return nil, fmt.Errorf("decoding signature: %w", err)
+	}
+	if !ed25519.Verify(pub, data, rawSig) {
+		return nil, ErrInvalidSignature
+	}
+	m := new(Manifest)
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LoadSigningKey reads a PEM encoded PKCS #8 ed25519 private key, as
+// generated by `openssl genpkey -algorithm ed25519`.
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is a %T, not an ed25519 key", path, key)
+	}
+	return edKey, nil
+}
+
+// LoadVerifyKey reads a PEM encoded ed25519 public key. A private key file
+// is accepted too, in which case its public half is used.
+func LoadVerifyKey(path string) (ed25519.PublicKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+	if block.Type == "PRIVATE KEY" {
+		priv, err := LoadSigningKey(path)
+		if err != nil {
+			return nil, err
+		}
+		return priv.Public().(ed25519.PublicKey), nil
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s is a %T, not an ed25519 key", path, key)
+	}
+	return edKey, nil
+}
+
+// MarshalSigningKey PEM encodes key as PKCS #8, the format read by
+// LoadSigningKey.
+func MarshalSigningKey(key ed25519.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// MarshalVerifyKey PEM encodes key, the format read by LoadVerifyKey.
+func MarshalVerifyKey(key ed25519.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errNoPEMBlock
+	}
+	return block, nil
+}
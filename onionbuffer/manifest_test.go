@@ -0,0 +1,109 @@
+package onionbuffer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSignManifest(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &OnionBuffer{
+		Name:      "testing_manifest",
+		Checksum:  "c7be1ed902fb8dd4d48997c6452f5d7e509fbcdbe2808b16bcf4edce4c07d14e",
+		Files:     []FileInfo{{Name: "note.txt", Size: 14, Checksum: "c7be1ed902fb8dd4d48997c6452f5d7e509fbcdbe2808b16bcf4edce4c07d14e"}},
+		CreatedAt: time.Now(),
+	}
+	data, err := b.Manifest().Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := SignManifest(data, priv)
+
+	m, err := VerifyManifest(data, sig, pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ShareID != b.Name || len(m.Files) != 1 || m.Files[0].Name != "note.txt" {
+		t.Errorf("unexpected manifest %+v", m)
+	}
+
+	data[len(data)-2] ^= 0xff
+	if _, err := VerifyManifest(data, sig, pub); err != ErrInvalidSignature {
+		t.Errorf("expected %v, got %v", ErrInvalidSignature, err)
+	}
+}
+
+func TestManifestStable(t *testing.T) {
+	b := &OnionBuffer{Name: "testing_manifest", CreatedAt: time.Now().Add(-time.Minute)}
+	if err := b.SetDeadline(time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.SetIdleTimeout(10 * time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	before, err := b.Manifest().Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A download moves the idle expiry, which must not change the manifest
+	if err := b.ClaimDownload(); err != nil {
+		t.Fatal(err)
+	}
+	b.FinishDownload(false, false)
+	after, err := b.Manifest().Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Errorf("expected the manifest to stay the same, got %s and %s", before, after)
+	}
+	if !bytes.Contains(after, []byte(`"deadline"`)) {
+		t.Errorf("expected the deadline in the manifest, got %s", after)
+	}
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "onionbox")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "key.pem")
+	if err := ioutil.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := LoadSigningKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(key, priv) {
+		t.Error("loaded signing key does not match")
+	}
+	verifyKey, err := LoadVerifyKey(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(verifyKey, pub) {
+		t.Error("loaded verify key does not match")
+	}
+}
@@ -1,10 +1,11 @@
 package onionbuffer
 
 import (
-	"archive/zip"
 	"bufio"
+	"bytes"
+	"errors"
 	"io"
-	"mime/multipart"
+	"io/ioutil"
 	"runtime"
 	"sync"
 	"syscall"
@@ -13,17 +14,55 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+var (
+	// ErrLimitReached is returned when every allowed download of a buffer has
+	// already been claimed.
+	ErrLimitReached = errors.New("download limit reached")
+	// ErrDestroyed is returned when reading a buffer which has been destroyed,
+	// such as one wiped on expiry while it was being downloaded.
+	ErrDestroyed = errors.New("buffer destroyed")
+)
+
+// FileInfo describes a single file stored inside an OnionBuffer's archive.
+type FileInfo struct {
+	Name     string `json:"name"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"sha256"`
+}
+
 // OnionBuffer struct
 type OnionBuffer struct {
 	sync.RWMutex
 	Name          string
 	Bytes         []byte
 	Checksum      string
+	Files         []FileInfo
+	Format        ArchiveFormat
+	MIMEType      string
 	Encrypted     bool
+	Sealed        bool
+	Size          int64
 	Downloads     int64
 	DownloadLimit int64
-	Expire        bool
-	ExpiresAt     time.Time
+	// inFlight counts claimed downloads which have not finished yet
+	inFlight int64
+	// Expire and ExpiresAt are the effective expiry derived from the
+	// buffer's lifetime policies below.
+	Expire    bool
+	ExpiresAt time.Time
+	// Deadline is an absolute time after which the buffer is wiped.
+	Deadline time.Time
+	// IdleTimeout wipes the buffer once it has gone this long without a
+	// successful download.
+	IdleTimeout  time.Duration
+	LastDownload time.Time
+	// BurnAfterRead wipes the buffer after its first successful download.
+	BurnAfterRead bool
+	burned        bool
+	// NotBefore embargoes the buffer until the given time.
+	NotBefore time.Time
+	CreatedAt time.Time
+	destroyed bool
 }
 
 // Destroy is mostly used to destroy temporary OnionBuffer objects after they
@@ -34,24 +73,101 @@ func (b *OnionBuffer) Destroy() error {
 	defer b.Unlock()
 
 	// Unlock bytes assigned to b so they can be reused for SWAP
-	// since b is being deleted
-	if err := b.Munlock(); err != nil {
-		return err
-	}
+	// since b is being deleted. The bytes are wiped even if this fails.
+	err := b.Munlock()
 
 	// nil out onionbuffer
+	wipe(b.Bytes)
 	b.Name = ""
 	b.Bytes = nil
 	b.Checksum = ""
+	b.Files = nil
+	b.Format = ""
+	b.MIMEType = ""
 	b.DownloadLimit = 0
 	b.Downloads = 0
+	b.inFlight = 0
 	b.Encrypted = false
+	b.Sealed = false
+	b.Size = 0
 	b.Expire = false
 	b.ExpiresAt = time.Time{}
+	b.Deadline = time.Time{}
+	b.IdleTimeout = 0
+	b.LastDownload = time.Time{}
+	b.BurnAfterRead = false
+	b.burned = false
+	b.NotBefore = time.Time{}
+	b.CreatedAt = time.Time{}
+	b.destroyed = true
 
+	return err
+}
+
+// ClaimDownload atomically reserves one of the buffer's downloads before a
+// transfer starts. Every successful claim must be paired with a call to
+// FinishDownload once the transfer ends. Claims are refused while the
+// buffer is embargoed or once it has expired or been destroyed.
+func (b *OnionBuffer) ClaimDownload() error {
+	b.Lock()
+	defer b.Unlock()
+	if err := b.claimable(time.Now()); err != nil {
+		return err
+	}
+	b.Downloads++
+	b.inFlight++
 	return nil
 }
 
+// Downloadable returns the error ClaimDownload would, without claiming a
+// download.
+func (b *OnionBuffer) Downloadable() error {
+	b.RLock()
+	defer b.RUnlock()
+	return b.claimable(time.Now())
+}
+
+// claimable returns why a download cannot be claimed at now, if it cannot.
+// The caller must hold at least a read lock.
+func (b *OnionBuffer) claimable(now time.Time) error {
+	if err := b.available(now); err != nil {
+		return err
+	}
+	if b.DownloadLimit != 0 && b.Downloads >= b.DownloadLimit {
+		return ErrLimitReached
+	}
+	// A burn after read buffer only ever hands out one download at a time
+	if b.BurnAfterRead && (b.burned || b.inFlight > 0) {
+		return ErrLimitReached
+	}
+	return nil
+}
+
+// FinishDownload ends a download claimed with ClaimDownload. If the transfer
+// failed and release is true, its slot is given back. A successful transfer
+// restarts the idle timeout and burns a burn after read buffer. It reports
+// whether the buffer is exhausted: its final allowed download has completed
+// and no other download is still in flight.
+func (b *OnionBuffer) FinishDownload(failed, release bool) bool {
+	b.Lock()
+	defer b.Unlock()
+	b.inFlight--
+	if failed && release {
+		b.Downloads--
+	}
+	if !failed {
+		b.LastDownload = time.Now()
+		b.updateExpiry()
+		if b.BurnAfterRead {
+			b.burned = true
+		}
+	}
+	if b.inFlight != 0 {
+		return false
+	}
+	return b.burned || (b.DownloadLimit != 0 && b.Downloads >= b.DownloadLimit)
+}
+
 // IsExpired is used to check if an OnionBuffer is expired or not.
 func (b *OnionBuffer) IsExpired() bool {
 	b.RLock()
@@ -66,45 +182,138 @@ func (b *OnionBuffer) IsExpired() bool {
 }
 
 // SetExpiration is used to set the expiration duration of the OnionBuffer.
+// It sets a deadline that far from now.
 func (b *OnionBuffer) SetExpiration(expiration string) error {
+	t, err := time.ParseDuration(expiration)
+	if err != nil {
+		return err
+	}
+	return b.SetDeadline(time.Now().Add(t))
+}
+
+// Seal encrypts the buffer's bytes at rest under the process enclave key.
+// The plaintext slice is wiped once it has been sealed, so only ciphertext
+// remains in memory until the buffer is streamed with WriteTo.
+func (b *OnionBuffer) Seal() error {
 	b.Lock()
 	defer b.Unlock()
-	t, err := time.ParseDuration(expiration)
+	if b.Sealed {
+		return nil
+	}
+	gcm, err := processEnclave.aead()
+	if err != nil {
+		return err
+	}
+	sealed, err := seal(gcm, b.Bytes)
 	if err != nil {
 		return err
 	}
-	b.Expire = true
-	b.ExpiresAt = time.Now().Add(t)
+	// Unlock and wipe the plaintext now that it has been sealed
+	_ = b.Munlock()
+	wipe(b.Bytes)
+	b.Size = int64(len(b.Bytes))
+	b.Bytes = sealed
+	b.Sealed = true
+	// Locking the sealed bytes from SWAP is best effort, they are ciphertext
+	_ = b.Mlock()
 	return nil
 }
 
-func WriteFilesToZip(w *zip.Writer, files chan *multipart.FileHeader, wg *sync.WaitGroup) error {
-	for {
-		select {
-		case fileHeader := <-files:
-			file, err := fileHeader.Open() // Open uploaded file
-			if err != nil {
-				return err
-			}
-
-			zBuffer, err := w.Create(fileHeader.Filename) // Create file in zip with same name
-			if err != nil {
-				return err
-			}
-			if err := writeBytesByChunk(file, zBuffer, 1024); err != nil { // Write file in chunks to zBuffer
-				return err
-			}
-			// Flush zipwriter to write compressed bytes to buffer
-			// before moving onto the next file
-			if err := w.Flush(); err != nil {
-				return err
-			}
-			if err := file.Close(); err != nil {
-				return err
-			}
-			wg.Done()
-		}
+// FileName returns the name recipients download the buffer as. Archives are
+// named after the buffer while single files keep their original name.
+func (b *OnionBuffer) FileName() string {
+	if b.Format == ArchiveNone && len(b.Files) == 1 {
+		return b.Files[0].Name
+	}
+	if b.Format == "" {
+		return b.Name + "." + ArchiveZip.Extension()
+	}
+	return b.Name + "." + b.Format.Extension()
+}
+
+// ContentType returns the MIME type recipients download the buffer as.
+func (b *OnionBuffer) ContentType() string {
+	if b.Format == ArchiveNone && b.MIMEType != "" {
+		return b.MIMEType
+	}
+	if b.Format == "" {
+		return ArchiveZip.ContentType()
+	}
+	return b.Format.ContentType()
+}
+
+// Len returns the length of the buffer's plaintext, whether sealed or not.
+func (b *OnionBuffer) Len() int64 {
+	if b.Sealed {
+		return b.Size
 	}
+	return int64(len(b.Bytes))
+}
+
+// newReader returns a reader over the buffer's plaintext. Sealed buffers are
+// opened one chunk at a time. The caller must not hold the buffer's lock,
+// the reader takes it for each read.
+func (b *OnionBuffer) newReader() (io.ReadCloser, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.destroyed {
+		return nil, ErrDestroyed
+	}
+	if !b.Sealed {
+		return &lockedReader{b: b, r: ioutil.NopCloser(bytes.NewReader(b.Bytes))}, nil
+	}
+	gcm, err := processEnclave.aead()
+	if err != nil {
+		return nil, err
+	}
+	return &lockedReader{b: b, r: newChunkReader(gcm, b.Bytes)}, nil
+}
+
+// lockedReader reads a buffer's bytes holding its read lock for one read at
+// a time, never while the plaintext is written on. A slow download thus
+// cannot hold up destroying the buffer, which instead fails the download
+// with ErrDestroyed.
+type lockedReader struct {
+	b *OnionBuffer
+	r io.ReadCloser
+}
+
+func (r *lockedReader) Read(p []byte) (int, error) {
+	r.b.RLock()
+	defer r.b.RUnlock()
+	if r.b.destroyed {
+		return 0, ErrDestroyed
+	}
+	return r.r.Read(p)
+}
+
+func (r *lockedReader) Close() error { return r.r.Close() }
+
+// WriteTo streams the buffer's plaintext to w, decrypting it in bounded
+// chunks if it is sealed.
+func (b *OnionBuffer) WriteTo(w io.Writer) (int64, error) {
+	r, err := b.newReader()
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(w, r)
+	if cerr := r.Close(); cerr != nil && err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+// ReadAll returns a copy of the buffer's whole plaintext. It is meant for
+// password encrypted buffers, whose plaintext is itself ciphertext.
+func (b *OnionBuffer) ReadAll() ([]byte, error) {
+	b.RLock()
+	size := b.Len()
+	b.RUnlock()
+	buf := bytes.NewBuffer(make([]byte, 0, size))
+	if _, err := b.WriteTo(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
 }
 
 func writeBytesByChunk(file io.Reader, bufWriter io.Writer, chunkSize int64) error {
@@ -113,9 +322,14 @@ func writeBytesByChunk(file io.Reader, bufWriter io.Writer, chunkSize int64) err
 	reader := bufio.NewReader(file) // Read uploaded file
 	// chunk, err := Allocate(int(chunkSize))
 	chunk := make([]byte, chunkSize)
-	if err != nil {
+	// Keep chunk out of core dumps and SWAP before anything is read into it
+	if err := dontDump(chunk); err != nil {
+		return err
+	}
+	if err := unix.Mlock(chunk); err != nil {
 		return err
 	}
+	defer func() { _ = unix.Munlock(chunk) }()
 	for {
 		if count, err = reader.Read(chunk); err != nil { // Read the specific chunk of uploaded file
 			break
@@ -123,13 +337,6 @@ func writeBytesByChunk(file io.Reader, bufWriter io.Writer, chunkSize int64) err
 		if _, err := bufWriter.Write(chunk[:count]); err != nil {
 			return err // Write the specific chunk to the new zip entry
 		}
-		// Advise the kernel not to dump. Ignore failure.
-		// Unable to reference unix.MADV_DONTDUMP, raw value is 0x10 per:
-		// https://godoc.org/golang.org/x/sys/unix
-		_ = unix.Madvise(chunk, 0x10)
-		if err := unix.Mlock(chunk); err != nil { // Lock memory allotted to chunk from being used in SWAP
-			return err
-		}
 	}
 	if err != io.EOF { // If not EOF, return the err
 		return err
@@ -140,10 +347,8 @@ func writeBytesByChunk(file io.Reader, bufWriter io.Writer, chunkSize int64) err
 
 func (b *OnionBuffer) Mlock() error {
 	if runtime.GOOS != "windows" && runtime.GOOS != "darwin" {
-		// Advise the kernel not to dump. Ignore failure.
-		// Unable to reference unix.MADV_DONTDUMP, raw value is 0x10 per:
-		// https://godoc.org/golang.org/x/sys/unix
-		if err := unix.Madvise(b.Bytes, 0x11); err != nil {
+		// Advise the kernel not to dump
+		if err := dontDump(b.Bytes); err != nil {
 			return err
 		}
 		if err := unix.Mlock(b.Bytes); err != nil { // Lock memory allotted to chunk from being used in SWAP
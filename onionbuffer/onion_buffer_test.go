@@ -6,6 +6,9 @@ import (
 	"crypto/subtle"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -108,6 +111,17 @@ func TestDestroy(t *testing.T) {
 	}
 }
 
+func TestMlockUnaligned(t *testing.T) {
+	// Buffers rarely start on a page, which madvise would refuse on its own
+	buf := &OnionBuffer{Bytes: make([]byte, 100)[3:]}
+	if err := buf.Mlock(); err != nil {
+		t.Fatal(err)
+	}
+	if err := buf.Munlock(); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestWriteBytesInChunks(t *testing.T) {
 	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
 	reader := bytes.NewReader(testFile)
@@ -158,6 +172,41 @@ func BenchmarkIOCopy(b *testing.B) {
 	}
 }
 
+func TestClaimDownload(t *testing.T) {
+	b := &OnionBuffer{Name: "testing_claim", DownloadLimit: 3}
+	var claimed int64
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := b.ClaimDownload(); err == nil {
+				atomic.AddInt64(&claimed, 1)
+			} else if err != ErrLimitReached {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if claimed != 3 {
+		t.Fatalf("expected 3 claimed downloads, got %d", claimed)
+	}
+
+	// A failed transfer releases its slot, then the remaining two finish
+	if b.FinishDownload(true, true) {
+		t.Error("buffer should not be exhausted after a released download")
+	}
+	if err := b.ClaimDownload(); err != nil {
+		t.Errorf("expected released slot to be claimable, got %v", err)
+	}
+	if b.FinishDownload(false, true) || b.FinishDownload(false, true) {
+		t.Error("buffer should not be exhausted while downloads are in flight")
+	}
+	if !b.FinishDownload(false, true) {
+		t.Error("expected buffer to be exhausted after its final download")
+	}
+}
+
 func TestIsExpired(t *testing.T) {
 	ob := &OnionBuffer{Name: "testing_expired", Bytes: new(bytes.Buffer).Bytes(), Expire: true, ExpiresAt: time.Now()}
 	if !ob.IsExpired() {
@@ -174,6 +223,30 @@ func TestSetExpiration(t *testing.T) {
 	}
 }
 
+// newFileHeaders builds multipart file headers for files, as if they had
+// been uploaded through the upload form.
+func newFileHeaders(t *testing.T, files map[string][]byte) []*multipart.FileHeader {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	for name, content := range files {
+		fw, err := w.CreateFormFile("files", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := fw.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	form, err := multipart.NewReader(body, w.Boundary()).ReadForm(32 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return form.File["files"]
+}
+
 //func mustOpen(f string) *os.File {
 //	r, err := os.Open(f)
 //	if err != nil {
@@ -0,0 +1,75 @@
+package onionbuffer
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+)
+
+// SourceFile is a file to be written into a share's archive.
+type SourceFile interface {
+	Name() string
+	Size() int64
+	// ContentType is the file's MIME type, kept when it is shared without
+	// an archive.
+	ContentType() string
+	Open() (io.ReadCloser, error)
+}
+
+// UploadedFile returns a SourceFile reading a file uploaded through a
+// multipart form.
+func UploadedFile(fileHeader *multipart.FileHeader) SourceFile {
+	return uploadedFile{fileHeader}
+}
+
+type uploadedFile struct {
+	fh *multipart.FileHeader
+}
+
+func (u uploadedFile) Name() string                 { return u.fh.Filename }
+func (u uploadedFile) Size() int64                  { return u.fh.Size }
+func (u uploadedFile) Open() (io.ReadCloser, error) { return u.fh.Open() }
+
+// ContentType trusts the browser's Content-Type before falling back on the
+// file extension.
+func (u uploadedFile) ContentType() string {
+	if ct := u.fh.Header.Get("Content-Type"); ct != "" {
+		if _, _, err := mime.ParseMediaType(ct); err == nil {
+			return ct
+		}
+	}
+	return extensionMIMEType(u.fh.Filename)
+}
+
+// LocalFile returns a SourceFile reading the regular file at path. It is
+// shared under its base name.
+func LocalFile(path string) (SourceFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, errors.New(path + " is not a regular file")
+	}
+	return localFile{path: path, size: info.Size()}, nil
+}
+
+type localFile struct {
+	path string
+	size int64
+}
+
+func (l localFile) Name() string                 { return filepath.Base(l.path) }
+func (l localFile) Size() int64                  { return l.size }
+func (l localFile) ContentType() string          { return extensionMIMEType(l.path) }
+func (l localFile) Open() (io.ReadCloser, error) { return os.Open(l.path) }
+
+func extensionMIMEType(name string) string {
+	if ct := mime.TypeByExtension(filepath.Ext(name)); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,60 @@
+package onionbuffer
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "note.txt")
+	if err := ioutil.WriteFile(path, []byte("This is a test"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := LocalFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Name() != "note.txt" || f.Size() != 14 {
+		t.Errorf("unexpected name %q or size %d", f.Name(), f.Size())
+	}
+	if ct := f.ContentType(); ct != "text/plain; charset=utf-8" {
+		t.Errorf("unexpected content type %q", ct)
+	}
+	if _, err := LocalFile(dir); err == nil {
+		t.Error("expected a directory to be refused")
+	}
+	if _, err := LocalFile(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+		t.Errorf("expected a not exist error, got %v", err)
+	}
+}
+
+func TestNewArchive(t *testing.T) {
+	files := map[string][]byte{"a.txt": []byte("a"), "b.txt": []byte("b")}
+	var sources []SourceFile
+	for _, fh := range newFileHeaders(t, files) {
+		sources = append(sources, UploadedFile(fh))
+	}
+	b, err := NewArchive(ArchiveZip, sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Files) != 2 || b.Format != ArchiveZip || b.CreatedAt.IsZero() {
+		t.Errorf("unexpected buffer %+v", b)
+	}
+	if ok, err := b.ValidateChecksum(); err != nil || !ok {
+		t.Errorf("expected a valid checksum, got %v, %v", ok, err)
+	}
+	extracted := extractArchive(t, ArchiveZip, b.Bytes)
+	for name, content := range files {
+		if !bytes.Equal(extracted[name], content) {
+			t.Errorf("unexpected content %q for %s", extracted[name], name)
+		}
+	}
+	if _, err := NewArchive(ArchiveNone, sources); err != ErrSingleFileOnly {
+		t.Errorf("expected %v, got %v", ErrSingleFileOnly, err)
+	}
+}
@@ -0,0 +1,141 @@
+package onionstore
+
+import (
+	"container/heap"
+	"context"
+	"time"
+
+	"github.com/ciehanski/onionbox/onionbuffer"
+)
+
+// expiry is a scheduled wipe of an OnionBuffer.
+type expiry struct {
+	buf   *onionbuffer.OnionBuffer
+	at    time.Time
+	index int
+}
+
+// expiryHeap is a min-heap of expiries ordered by their deadline.
+type expiryHeap []*expiry
+
+func (h expiryHeap) Len() int           { return len(h) }
+func (h expiryHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+func (h expiryHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *expiryHeap) Push(x interface{}) {
+	e := x.(*expiry)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *expiryHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	old[len(old)-1] = nil
+	e.index = -1
+	*h = old[:len(old)-1]
+	return e
+}
+
+// schedule adds, moves or removes b's expiry. The caller must hold the store
+// lock. Deadlines keep the monotonic clock reading from time.Now, so wall
+// clock jumps neither wipe a buffer early nor let it outlive its expiry.
+func (s *OnionStore) schedule(b *onionbuffer.OnionBuffer, expire bool, at time.Time) {
+	e, scheduled := s.expiries[b]
+	switch {
+	case !expire && scheduled:
+		heap.Remove(&s.expiryHeap, e.index)
+		delete(s.expiries, b)
+	case expire && scheduled:
+		e.at = at
+		heap.Fix(&s.expiryHeap, e.index)
+	case expire:
+		e = &expiry{buf: b, at: at}
+		heap.Push(&s.expiryHeap, e)
+		s.expiries[b] = e
+	default:
+		return
+	}
+	// Wake the scheduler so it re-arms its timer for the new earliest expiry
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Reschedule must be called whenever the expiry of a buffer already in the
+// store changes.
+func (s *OnionStore) Reschedule(b *onionbuffer.OnionBuffer) {
+	b.RLock()
+	name, expire, at := b.Name, b.Expire, b.ExpiresAt
+	b.RUnlock()
+
+	s.Lock()
+	defer s.Unlock()
+	if s.BufferFiles[name] == b {
+		s.schedule(b, expire, at)
+	}
+}
+
+// DestroyExpiredBuffers destroys OnionBuffers the moment they expire. It
+// sleeps until the earliest scheduled expiry and returns once ctx is done.
+func (s *OnionStore) DestroyExpiredBuffers(ctx context.Context) error {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		s.RLock()
+		pending := len(s.expiryHeap) > 0
+		var next time.Duration
+		if pending {
+			next = time.Until(s.expiryHeap[0].at)
+		}
+		s.RUnlock()
+
+		// Re-arm the timer, draining it if it already fired
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		var fired <-chan time.Time
+		if pending {
+			if next < 0 {
+				next = 0
+			}
+			timer.Reset(next)
+			fired = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.wake:
+		case <-fired:
+			s.destroyDue()
+		}
+	}
+}
+
+// destroyDue destroys every buffer whose expiry has passed. Failing to
+// munlock a buffer must not stop the others from being wiped, so errors
+// are ignored here as they were by the polling loop this replaced.
+func (s *OnionStore) destroyDue() {
+	var due []*onionbuffer.OnionBuffer
+	s.Lock()
+	now := time.Now()
+	for len(s.expiryHeap) > 0 && !now.Before(s.expiryHeap[0].at) {
+		e := heap.Pop(&s.expiryHeap).(*expiry)
+		delete(s.expiries, e.buf)
+		due = append(due, e.buf)
+	}
+	s.Unlock()
+
+	for _, b := range due {
+		_ = s.Destroy(b)
+	}
+}
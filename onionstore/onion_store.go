@@ -1,56 +1,125 @@
 package onionstore
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
 	"errors"
-	"runtime"
 	"sync"
 	"time"
 
-	"golang.org/x/sys/unix"
-
 	"github.com/ciehanski/onionbox/onionbuffer"
 )
 
+var (
+	// ErrQuotaExceeded is returned when adding a buffer would take the store
+	// past its memory quota.
+	ErrQuotaExceeded = errors.New("store memory quota exceeded")
+	// ErrNotFound is returned when looking up a buffer which is not in the
+	// store.
+	ErrNotFound = errors.New("buffer not found")
+	// ErrExists is returned when adding a buffer under a name already in the
+	// store.
+	ErrExists = errors.New("buffer with that name already exists")
+)
+
 type OnionStore struct {
 	sync.RWMutex
 	BufferFiles map[string]*onionbuffer.OnionBuffer
+	// Quota caps the bytes held by all buffers in the store. Zero means
+	// no limit.
+	Quota      int64
+	used       int64
+	expiryHeap expiryHeap
+	expiries   map[*onionbuffer.OnionBuffer]*expiry
+	wake       chan struct{}
 }
 
 // NewStore creates a nil onionstore.
 func NewStore() *OnionStore {
-	return &OnionStore{BufferFiles: make(map[string]*onionbuffer.OnionBuffer)}
+	return &OnionStore{
+		BufferFiles: make(map[string]*onionbuffer.OnionBuffer),
+		expiries:    make(map[*onionbuffer.OnionBuffer]*expiry),
+		wake:        make(chan struct{}, 1),
+	}
 }
 
 func (s *OnionStore) Add(b *onionbuffer.OnionBuffer) error {
+	// Seal the onionbuffer's bytes at rest before it becomes reachable
+	if err := b.Seal(); err != nil {
+		return err
+	}
+
 	// Lock the onionbuffer to avoid conflicts
 	b.Lock()
 	defer b.Unlock()
 
-	// Check if onionbuffer already exists
-	if s.Exists(b.Name) {
-		return errors.New("onionbuffer with that name already exists")
-	}
-
 	s.Lock()
+	// Check if onionbuffer already exists, under the same lock as charging
+	// its bytes so concurrent adds of one name cannot both succeed
+	if _, exists := s.BufferFiles[b.Name]; exists {
+		s.Unlock()
+		return ErrExists
+	}
+	size := int64(len(b.Bytes))
+	if s.Quota > 0 && s.used+size > s.Quota {
+		s.Unlock()
+		return ErrQuotaExceeded
+	}
+	s.used += size
 	s.BufferFiles[b.Name] = b
+	s.schedule(b, b.Expire, b.ExpiresAt)
 	s.Unlock()
-	// Advise the kernel not to dump. Ignore failure.
-	// Unable to reference unix.MADV_DONTDUMP, raw value is 0x10 per:
-	// https://godoc.org/golang.org/x/sys/unix
-	if runtime.GOOS != "windows" {
-		_ = unix.Madvise(b.Bytes, 0x10)
-	}
-	// Lock bytes from SWAP
+	// Keep bytes out of core dumps and SWAP
 	if err := b.Mlock(); err != nil {
 		return err
 	}
 	return nil
 }
 
+// Get returns the named buffer, or nil if it does not exist or has expired
+// and is waiting to be destroyed.
 func (s *OnionStore) Get(bufName string) *onionbuffer.OnionBuffer {
+	b, _ := s.Find(bufName)
+	return b
+}
+
+// Find returns the named buffer, ErrNotFound if it does not exist, or
+// onionbuffer.ErrExpired if it has expired and is waiting to be destroyed.
+func (s *OnionStore) Find(bufName string) (*onionbuffer.OnionBuffer, error) {
 	s.RLock()
-	defer s.RUnlock()
-	return s.BufferFiles[bufName]
+	b := s.BufferFiles[bufName]
+	s.RUnlock()
+	if b == nil {
+		return nil, ErrNotFound
+	}
+	if b.IsExpired() {
+		return nil, onionbuffer.ErrExpired
+	}
+	return b, nil
+}
+
+// FindConstantTime is Find taking the same time whether or not the buffer
+// exists, so lookups do not tell which names are in the store. It compares
+// the name against every buffer's in constant time, hashed to the same
+// length, scanning the whole store.
+func (s *OnionStore) FindConstantTime(bufName string) (*onionbuffer.OnionBuffer, error) {
+	want := sha256.Sum256([]byte(bufName))
+	var found *onionbuffer.OnionBuffer
+	s.RLock()
+	for name, b := range s.BufferFiles {
+		have := sha256.Sum256([]byte(name))
+		if subtle.ConstantTimeCompare(want[:], have[:]) == 1 {
+			found = b
+		}
+	}
+	s.RUnlock()
+	if found == nil {
+		return nil, ErrNotFound
+	}
+	if found.IsExpired() {
+		return nil, onionbuffer.ErrExpired
+	}
+	return found, nil
 }
 
 func (s *OnionStore) Exists(bufName string) bool {
@@ -60,22 +129,49 @@ func (s *OnionStore) Exists(bufName string) bool {
 	return exists
 }
 
+// Destroy removes b from the store and wipes it. The store is unlocked
+// before b is, so destroying a buffer which is still being downloaded does
+// not hold up the rest of the store.
 func (s *OnionStore) Destroy(b *onionbuffer.OnionBuffer) error {
-	s.Lock()
-	defer s.Unlock()
+	b.RLock()
+	name, size := b.Name, int64(len(b.Bytes))
+	b.RUnlock()
+
 	// Remove from store
-	if _, ok := s.BufferFiles[b.Name]; ok {
-		delete(s.BufferFiles, b.Name)
-		if err := b.Destroy(); err != nil {
-			return err
-		}
+	s.Lock()
+	if s.BufferFiles[name] != b {
+		s.Unlock()
+		return nil
 	}
-	return nil
+	delete(s.BufferFiles, name)
+	s.schedule(b, false, time.Time{})
+	s.used -= size
+	s.Unlock()
+	return b.Destroy()
+}
+
+// FinishDownload ends a download of b claimed with b.ClaimDownload. b is
+// destroyed as soon as it is exhausted or burnt, otherwise its expiry is
+// rescheduled since a successful download restarts its idle timeout. It
+// reports whether b was destroyed.
+func (s *OnionStore) FinishDownload(b *onionbuffer.OnionBuffer, failed, release bool) (bool, error) {
+	if b.FinishDownload(failed, release) {
+		return true, s.Destroy(b)
+	}
+	s.Reschedule(b)
+	return false, nil
 }
 
 func (s *OnionStore) DestroyAll() error {
 	if s.BufferFiles != nil {
+		// Snapshot the buffers so Destroy can take the store lock itself
+		s.RLock()
+		buffers := make([]*onionbuffer.OnionBuffer, 0, len(s.BufferFiles))
 		for _, b := range s.BufferFiles {
+			buffers = append(buffers, b)
+		}
+		s.RUnlock()
+		for _, b := range buffers {
 			if err := s.Destroy(b); err != nil {
 				return err
 			}
@@ -84,22 +180,3 @@ func (s *OnionStore) DestroyAll() error {
 	}
 	return errors.New("store already empty")
 }
-
-// DestroyExpiredBuffers will indefinitely loop through the store and destroy
-// expired OnionBuffers.
-func (s *OnionStore) DestroyExpiredBuffers() error {
-	for {
-		select {
-		case <-time.After(time.Second * 15):
-			if s != nil {
-				for _, f := range s.BufferFiles {
-					if f.Expire {
-						if f.ExpiresAt.Equal(time.Now()) || f.ExpiresAt.Before(time.Now()) {
-							s.Destroy(f)
-						}
-					}
-				}
-			}
-		}
-	}
-}
@@ -1,7 +1,11 @@
 package onionstore
 
 import (
+	"bytes"
+	"context"
+	"io"
 	"io/ioutil"
+	"sync"
 	"testing"
 	"time"
 
@@ -18,6 +22,39 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestFind(t *testing.T) {
+	os := NewStore()
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	oBuf := onionbuffer.OnionBuffer{Name: "testing_find", Bytes: testFile}
+	_ = os.Add(&oBuf)
+	if b, err := os.Find("testing_find"); b != &oBuf || err != nil {
+		t.Errorf("expected the buffer, got %v", err)
+	}
+	if _, err := os.Find("testing_missing"); err != ErrNotFound {
+		t.Errorf("expected %v, got %v", ErrNotFound, err)
+	}
+	oBuf.Expire, oBuf.ExpiresAt = true, time.Now().Add(-time.Minute)
+	if _, err := os.Find("testing_find"); err != onionbuffer.ErrExpired {
+		t.Errorf("expected %v, got %v", onionbuffer.ErrExpired, err)
+	}
+}
+
+func TestFindConstantTime(t *testing.T) {
+	os := NewStore()
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	oBuf := onionbuffer.OnionBuffer{Name: "testing_find_constant", Bytes: testFile}
+	_ = os.Add(&oBuf)
+	_ = os.Add(&onionbuffer.OnionBuffer{Name: "testing_find_other", Bytes: []byte("other")})
+	if b, err := os.FindConstantTime("testing_find_constant"); b != &oBuf || err != nil {
+		t.Errorf("expected the buffer, got %v", err)
+	}
+	for _, name := range []string{"testing_find", "testing_find_constant_", ""} {
+		if _, err := os.FindConstantTime(name); err != ErrNotFound {
+			t.Errorf("%q: expected %v, got %v", name, ErrNotFound, err)
+		}
+	}
+}
+
 func TestExists(t *testing.T) {
 	os := NewStore()
 	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
@@ -38,7 +75,7 @@ func TestAdd(t *testing.T) {
 	if len(os.BufferFiles["testing_add"].Bytes) == 0 {
 		t.Errorf("bytes not added to onionstore")
 	}
-	if len(os.BufferFiles["testing_add"].Bytes) != len(testFile) {
+	if os.BufferFiles["testing_add"].Len() != int64(len(testFile)) {
 		t.Error("incorrect bytes added")
 	}
 	if os.BufferFiles["testing_add"].Name != "testing_add" {
@@ -61,6 +98,103 @@ func TestDestroy(t *testing.T) {
 	}
 }
 
+func TestDestroyDuringDownload(t *testing.T) {
+	os := NewStore()
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	// Repeat the test file so the download spans several chunks
+	oBuf := onionbuffer.OnionBuffer{Name: "testing_destroy_download", Bytes: bytes.Repeat(testFile, 16)}
+	other := onionbuffer.OnionBuffer{Name: "testing_destroy_other", Bytes: testFile}
+	_ = os.Add(&oBuf)
+	_ = os.Add(&other)
+
+	// A recipient reading one chunk, then stalling
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		_, err := oBuf.WriteTo(pw)
+		done <- err
+	}()
+	if _, err := pr.Read(make([]byte, 1024)); err != nil {
+		t.Fatal(err)
+	}
+
+	destroyed := make(chan error, 1)
+	go func() { destroyed <- os.Destroy(&oBuf) }()
+	select {
+	case err := <-destroyed:
+		if err != nil {
+			t.Error(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a stalled download not to hold up destroying its buffer")
+	}
+	if os.Get("testing_destroy_other") != &other {
+		t.Error("expected the other buffer to stay in the store")
+	}
+
+	go ioutil.ReadAll(pr)
+	if err := <-done; err != onionbuffer.ErrDestroyed {
+		t.Errorf("expected the download to fail with %v, got %v", onionbuffer.ErrDestroyed, err)
+	}
+}
+
+func TestAddDuplicate(t *testing.T) {
+	os := NewStore()
+	// Concurrent adds of one name charge the quota only for the one stored
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- os.Add(&onionbuffer.OnionBuffer{Name: "testing_duplicate", Bytes: make([]byte, 60)})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	added := 0
+	for err := range errs {
+		switch err {
+		case nil:
+			added++
+		case ErrExists:
+		default:
+			if err.Error() != "invalid argument" {
+				t.Error(err)
+			}
+			added++
+		}
+	}
+	if added != 1 {
+		t.Errorf("expected one add to succeed, got %d", added)
+	}
+	if want := int64(len(os.BufferFiles["testing_duplicate"].Bytes)); os.used != want {
+		t.Errorf("expected only the stored buffer's %d bytes to be charged, got %d", want, os.used)
+	}
+}
+
+func TestAddQuota(t *testing.T) {
+	os := NewStore()
+	os.Quota = 100
+	oBuf1 := onionbuffer.OnionBuffer{Name: "testing_quota1", Bytes: make([]byte, 60)}
+	oBuf2 := onionbuffer.OnionBuffer{Name: "testing_quota2", Bytes: make([]byte, 60)}
+	if err := os.Add(&oBuf1); err != nil && err.Error() != "invalid argument" {
+		t.Fatal(err)
+	}
+	if err := os.Add(&oBuf2); err != ErrQuotaExceeded {
+		t.Errorf("expected %v, got %v", ErrQuotaExceeded, err)
+	}
+	if os.Exists("testing_quota2") {
+		t.Error("buffer over quota should not be added")
+	}
+
+	// Destroying a buffer frees its share of the quota
+	_ = os.Destroy(&oBuf1)
+	if err := os.Add(&oBuf2); err != nil && err.Error() != "invalid argument" {
+		t.Error(err)
+	}
+}
+
 func TestDestroyAll(t *testing.T) {
 	os := NewStore()
 	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
@@ -84,20 +218,57 @@ func TestDestroyAll(t *testing.T) {
 }
 
 func TestDestroyExpiredBuffers(t *testing.T) {
-	t.Skip()
 	os := NewStore()
 	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
-	oBuf := onionbuffer.OnionBuffer{Name: "testing_destroyexpired", Bytes: testFile, Expire: true, ExpiresAt: time.Now()}
+	oBuf := onionbuffer.OnionBuffer{Name: "testing_destroyexpired", Bytes: testFile, Expire: true, ExpiresAt: time.Now().Add(100 * time.Millisecond)}
 	_ = os.Add(&oBuf)
-	go func() {
-		if err := os.DestroyExpiredBuffers(); err != nil {
-			if err.Error() != "invalid argument" {
-				t.Error(err)
-			}
-		}
-	}()
-	time.Sleep(time.Second * 20)
-	if b := os.Get(oBuf.Name); b != nil {
+	keep := onionbuffer.OnionBuffer{Name: "testing_keep", Bytes: testFile}
+	_ = os.Add(&keep)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- os.DestroyExpiredBuffers(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	if b := os.Get("testing_destroyexpired"); b == nil {
+		t.Error("buffer destroyed before it expired")
+	}
+	time.Sleep(250 * time.Millisecond)
+	if b := os.Get("testing_destroyexpired"); b != nil {
 		t.Errorf("should have failed to get after destroy")
 	}
+	if b := os.Get("testing_keep"); b == nil {
+		t.Error("buffer without expiry should not be destroyed")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Errorf("expected %v, got %v", context.Canceled, err)
+		}
+	case <-time.After(time.Second):
+		t.Error("scheduler did not stop after cancel")
+	}
+}
+
+func TestReschedule(t *testing.T) {
+	os := NewStore()
+	testFile, _ := ioutil.ReadFile("../tests/gopher.jpg")
+	oBuf := onionbuffer.OnionBuffer{Name: "testing_reschedule", Bytes: testFile, Expire: true, ExpiresAt: time.Now().Add(time.Hour)}
+	_ = os.Add(&oBuf)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = os.DestroyExpiredBuffers(ctx) }()
+
+	oBuf.Lock()
+	oBuf.ExpiresAt = time.Now().Add(50 * time.Millisecond)
+	oBuf.Unlock()
+	os.Reschedule(&oBuf)
+
+	time.Sleep(250 * time.Millisecond)
+	if b := os.Get("testing_reschedule"); b != nil {
+		t.Error("rescheduled buffer should have been destroyed")
+	}
 }
@@ -11,12 +11,19 @@ const DownloadHTML = `<!DOCTYPE html>
     <body>
         <center>
         <h2>Click below to download your files securely.</h2>
+        {{with .Lifetime}}
+        {{if not .Available}}<p><strong>Not available before {{.NotBefore.UTC.Format "2006-01-02 15:04 MST"}}.</strong></p>{{end}}
+        {{if not .ExpiresAt.IsZero}}<p>Expires {{.ExpiresAt.UTC.Format "2006-01-02 15:04 MST"}}{{if .IdleTimeout}}, or {{.IdleTimeout}} after the last download{{end}}.</p>{{end}}
+        {{if .BurnAfterRead}}<p>This share is destroyed after its first download.</p>{{else if ge .DownloadsLeft 0}}<p>Downloads left: {{.DownloadsLeft}}</p>{{end}}
+        {{end}}
         <form method="post">
-            <input type="hidden" name="token" value="{{.}}" required/>
+            <input type="hidden" name="token" value="{{.CSRF}}" required/>
             <h4>Enter Password:</h4>
             <input type="password" name="password" required><br>
             <input type="submit" class="button is-link" value="Download">
         </form>
+        <p>SHA-256: <code>{{.Checksum}}</code></p>
+        <p><a href="{{.Name}}/manifest.json">manifest.json</a>{{if .Signed}} &middot; <a href="{{.Name}}/manifest.sig">manifest.sig</a>{{end}}</p>
 		</center>
     </body>
 </html>`
@@ -12,18 +12,33 @@ const UploadHTML = `<!DOCTYPE html>
 		<center>
 			<br><br><br>
 			<h1 class="title is-1">[onionbox]</h1><br>
+			{{if .Banner}}<p>{{.Banner}}</p><br>{{end}}
 			<h2>Please select the file(s) you would like to securely share:</h2>
 			<form method="post" enctype="multipart/form-data" action="/">
 				<input type="file" name="files" required multiple><br>
-				<input type="hidden" name="token" value="{{.}}" required/>
+				<input type="hidden" name="token" value="{{.CSRF}}" required/>
 				<br>
 				<h3 class="subtitle is-3">Advanced Options</h3>
+				Archive format: 
+				<select name="archive">
+					<option value="zip" selected>zip</option>
+					<option value="tar.gz">tar.gz</option>
+					<option value="tar.zst">tar.zst</option>
+					<option value="none">none (single file only)</option>
+				</select><br>
 				<input type="checkbox" name="password_enabled"> Protect with password: 
 				<input type="password" name="password"><br>
 				<input type="checkbox" name="limit_downloads"> Limit downloads: 
 				<input type="number" name="download_limit"><br>
 				<input type="checkbox" name="expire"> Automatically expire download link (in minutes): 
-				<input type="number" name="expiration_time"><br><br>
+				<input type="number" name="expiration_time"><br>
+				Expire download link at (UTC): 
+				<input type="datetime-local" name="expires_at"><br>
+				<input type="checkbox" name="idle_expire"> Expire after no downloads for (in minutes): 
+				<input type="number" name="idle_timeout"><br>
+				<input type="checkbox" name="burn"> Burn after first successful download<br>
+				Not available before (UTC): 
+				<input type="datetime-local" name="not_before"><br><br>
 				<input type="submit" class="button is-link" value="Upload">
 			</form>
 		</center>
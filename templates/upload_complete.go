@@ -14,6 +14,8 @@ const UploadCompleteHTML = `<!DOCTYPE html>
 			<h1 class="title is-1">[onionbox]</h1><br>
 			<h2>Files uploaded. Please share this link with your recipient(s):</h2>
 			<h1><b>{{.OnionAddr}}</b></h1>
+			<p>SHA-256: <code>{{.Checksum}}</code></p>
+			<p><a href="{{.OnionAddr}}/manifest.json">manifest.json</a>{{if .Signed}} &middot; <a href="{{.OnionAddr}}/manifest.sig">manifest.sig</a>{{end}}</p>
 			<br>
 			<img src="data:image/png;base64,{{.QR}}">
 		</center>